@@ -0,0 +1,16 @@
+data "theoffice_api_health" "example" {
+  season          = 1
+  timeout_seconds = 5
+}
+
+check "api_health" {
+  assert {
+    condition     = data.theoffice_api_health.example.healthy
+    error_message = "theOffice API is unhealthy: ${coalesce(data.theoffice_api_health.example.error, "unknown error")}"
+  }
+
+  assert {
+    condition     = data.theoffice_api_health.example.catalogue_complete
+    error_message = "theOffice API at ${data.theoffice_api_health.example.endpoint} is missing episodes of season ${data.theoffice_api_health.example.season}."
+  }
+}
@@ -0,0 +1,3 @@
+data "theoffice_character_stats" "example" {
+  season = 3
+}
@@ -0,0 +1,11 @@
+data "theoffice_dataset_diff" "mirror" {
+  compare_endpoint = "https://theoffice-mirror.example.com"
+  seasons          = [1, 2]
+}
+
+check "mirror_in_sync" {
+  assert {
+    condition     = data.theoffice_dataset_diff.mirror.in_sync
+    error_message = "The mirror has drifted: ${length(data.theoffice_dataset_diff.mirror.quote_changes)} quotes and ${length(data.theoffice_dataset_diff.mirror.link_changes)} links differ."
+  }
+}
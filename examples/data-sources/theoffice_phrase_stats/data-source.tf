@@ -0,0 +1,5 @@
+data "theoffice_phrase_stats" "example" {
+  season     = 2
+  characters = ["Dwight"]
+  limit      = 5
+}
@@ -0,0 +1,6 @@
+data "theoffice_quote_index_search" "example" {
+  season    = 2
+  query     = "\"that's what she said\" dundie* beats~"
+  fuzziness = 1
+  limit     = 5
+}
@@ -0,0 +1,4 @@
+data "theoffice_scenes" "example" {
+  season  = 2
+  episode = 1
+}
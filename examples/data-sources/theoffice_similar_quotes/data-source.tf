@@ -0,0 +1,15 @@
+data "theoffice_similar_quotes" "example" {
+  text  = "Identity theft is not a joke, Jim!"
+  limit = 5
+}
+
+data "theoffice_similar_quotes" "by_quote" {
+  season = 2
+
+  quote = {
+    season    = 2
+    episode   = 1
+    scene     = 1
+    character = "Dwight"
+  }
+}
@@ -0,0 +1,6 @@
+data "theoffice_transcript" "example" {
+  season  = 2
+  episode = 1
+  format  = "markdown"
+  width   = 80
+}
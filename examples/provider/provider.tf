@@ -1 +1,8 @@
 provider "theoffice" {}
+
+# Data sources read season 2 and leave out Toby unless they say otherwise.
+provider "theoffice" {
+  alias              = "season_two"
+  default_season     = 2
+  exclude_characters = ["Toby"]
+}
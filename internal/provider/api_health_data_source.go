@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultHealthTimeout = 10 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &APIHealthDataSource{}
+
+func NewAPIHealthDataSource() datasource.DataSource {
+	return &APIHealthDataSource{}
+}
+
+// APIHealthDataSource defines the data source implementation.
+type APIHealthDataSource struct {
+	providerData *providerData
+}
+
+// APIHealthDataSourceModel describes the data source data model.
+type APIHealthDataSourceModel struct {
+	Season            types.Int64           `tfsdk:"season"`
+	TimeoutSeconds    types.Int64           `tfsdk:"timeout_seconds"`
+	Endpoint          types.String          `tfsdk:"endpoint"`
+	Healthy           types.Bool            `tfsdk:"healthy"`
+	Status            types.Int64           `tfsdk:"status"`
+	LatencyMS         types.Int64           `tfsdk:"latency_ms"`
+	ResponseSize      types.Int64           `tfsdk:"response_size"`
+	CatalogueComplete types.Bool            `tfsdk:"catalogue_complete"`
+	Error             types.String          `tfsdk:"error"`
+	Endpoints         []endpointHealthModel `tfsdk:"endpoints"`
+	ID                types.String          `tfsdk:"id"`
+}
+
+type endpointHealthModel struct {
+	Endpoint          types.String `tfsdk:"endpoint"`
+	Healthy           types.Bool   `tfsdk:"healthy"`
+	Status            types.Int64  `tfsdk:"status"`
+	LatencyMS         types.Int64  `tfsdk:"latency_ms"`
+	ResponseSize      types.Int64  `tfsdk:"response_size"`
+	CatalogueComplete types.Bool   `tfsdk:"catalogue_complete"`
+	Error             types.String `tfsdk:"error"`
+}
+
+func (d *APIHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_health"
+}
+
+// endpointHealthAttributes returns the schema of an endpoint's health, with
+// descriptions ending in suffix.
+func endpointHealthAttributes(suffix string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"endpoint": schema.StringAttribute{
+			Description: "The address of the endpoint" + suffix + ".",
+			Computed:    true,
+		},
+		"healthy": schema.BoolAttribute{
+			Description: "Whether the endpoint" + suffix + " answered the probe successfully.",
+			Computed:    true,
+		},
+		"status": schema.Int64Attribute{
+			Description: "The HTTP status of the probe's response. Null when no response was received.",
+			Computed:    true,
+		},
+		"latency_ms": schema.Int64Attribute{
+			Description: "How long the probe's response took to be received, in milliseconds.",
+			Computed:    true,
+		},
+		"response_size": schema.Int64Attribute{
+			Description: "The size of the body of the probe's response, in bytes.",
+			Computed:    true,
+		},
+		"catalogue_complete": schema.BoolAttribute{
+			Description: "Whether the endpoint" + suffix + " serves every episode of the season. False when it is unhealthy.",
+			Computed:    true,
+		},
+		"error": schema.StringAttribute{
+			Description: "Why the endpoint" + suffix + " is unhealthy or its catalogue could not be checked. Null otherwise.",
+			Computed:    true,
+		},
+	}
+}
+
+func (d *APIHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := endpointHealthAttributes(" requests are sent to: the first healthy endpoint, or the first endpoint when none is healthy")
+	attributes["id"] = schema.StringAttribute{
+		Description: "Placeholder identifier attribute.",
+		Computed:    true,
+	}
+	attributes["season"] = schema.Int64Attribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Season number whose catalogue of episodes is checked. Defaults to the provider's default_season, or 1.",
+		Validators: []validator.Int64{
+			seasonValidator(),
+		},
+	}
+	attributes["timeout_seconds"] = schema.Int64Attribute{
+		Optional:    true,
+		Description: fmt.Sprintf("How long to wait for each request, in seconds (default: %d)", int(defaultHealthTimeout.Seconds())),
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+	attributes["endpoints"] = schema.ListNestedAttribute{
+		Description: "The health of every endpoint, in the order of the provider's endpoints.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: endpointHealthAttributes(""),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Probes the provider's endpoints with a lightweight request, and checks that they serve every " +
+			"episode of a season. Failures are reported as attributes rather than errors, so that `check` blocks can assert " +
+			"on them without failing the plan. Requests aren't retried nor failed over.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *APIHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *APIHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data APIHealthDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, types.Int64Null(), false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Season.IsNull() {
+		data.Season = types.Int64Value(1)
+	}
+
+	timeout := defaultHealthTimeout
+	if !data.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(data.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+
+	client, err := d.providerData.newProbeClient(timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("error configuring theOffice client", err.Error())
+		return
+	}
+
+	var results []theoffice.EndpointHealth
+	err = d.providerData.limit(ctx, func() error {
+		results = client.CheckHealth(ctx, int(data.Season.ValueInt64()))
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Check theOffice API Health", err.Error())
+		return
+	}
+
+	data.Endpoints = []endpointHealthModel{}
+	for _, h := range results {
+		data.Endpoints = append(data.Endpoints, endpointHealthState(h))
+	}
+
+	active := data.Endpoints[0]
+	for _, h := range data.Endpoints {
+		if h.Healthy.ValueBool() {
+			active = h
+			break
+		}
+	}
+	data.Endpoint = active.Endpoint
+	data.Healthy = active.Healthy
+	data.Status = active.Status
+	data.LatencyMS = active.LatencyMS
+	data.ResponseSize = active.ResponseSize
+	data.CatalogueComplete = active.CatalogueComplete
+	data.Error = active.Error
+
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read api health data source", map[string]any{"healthy": data.Healthy.ValueBool()})
+}
+
+func endpointHealthState(h theoffice.EndpointHealth) endpointHealthModel {
+	state := endpointHealthModel{
+		Endpoint:          types.StringValue(h.Address),
+		Healthy:           types.BoolValue(h.Healthy()),
+		Status:            types.Int64Null(),
+		LatencyMS:         types.Int64Value(h.Latency.Milliseconds()),
+		ResponseSize:      types.Int64Value(int64(h.Size)),
+		CatalogueComplete: types.BoolValue(h.CatalogueComplete),
+		Error:             types.StringNull(),
+	}
+	if h.Status != 0 {
+		state.Status = types.Int64Value(int64(h.Status))
+	}
+	if h.Err != nil {
+		state.Error = types.StringValue(h.Err.Error())
+	}
+
+	return state
+}
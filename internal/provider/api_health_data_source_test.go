@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAPIHealthDataSource(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAPIHealthDataSourceConfig(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoint", srv.URL),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "healthy", "true"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "status", "200"),
+					resource.TestCheckResourceAttrSet("data.theoffice_api_health.test", "latency_ms"),
+					resource.TestCheckResourceAttrSet("data.theoffice_api_health.test", "response_size"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "season", "1"),
+					// The stub serves 2 of the 6 episodes of season 1.
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "catalogue_complete", "false"),
+					resource.TestCheckNoResourceAttr("data.theoffice_api_health.test", "error"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoints.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIHealthDataSource_unhealthy(t *testing.T) {
+	failing := theofficetest.NewServer(theofficetest.WithFaults(theofficetest.Faults{StatusCode: http.StatusServiceUnavailable}))
+	defer failing.Close()
+	mirror := theofficetest.NewServer()
+	defer mirror.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAPIHealthDataSourceConfig_endpoints(failing.URL, mirror.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoint", mirror.URL),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "healthy", "true"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoints.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoints.0.healthy", "false"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoints.0.status", "503"),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoints.0.catalogue_complete", "false"),
+					resource.TestMatchResourceAttr("data.theoffice_api_health.test", "endpoints.0.error", regexp.MustCompile(`bad status \(503\)`)),
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "endpoints.1.healthy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIHealthDataSource_unreachable(t *testing.T) {
+	down := theofficetest.NewServer()
+	down.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAPIHealthDataSourceConfig(down.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_api_health.test", "healthy", "false"),
+					resource.TestCheckNoResourceAttr("data.theoffice_api_health.test", "status"),
+					resource.TestCheckResourceAttrSet("data.theoffice_api_health.test", "error"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAPIHealthDataSourceConfig(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_api_health" "test" {
+  timeout_seconds = 5
+}
+`, endpoint)
+}
+
+func testAccAPIHealthDataSourceConfig_endpoints(primary, mirror string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoints = [%[1]q, %[2]q]
+}
+
+data "theoffice_api_health" "test" {
+  season = 2
+}
+`, primary, mirror)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sync"
+)
+
+// cache memoizes values by key. Concurrent gets of the same key wait for a
+// single fetch, and failed fetches aren't cached so that a later get retries.
+type cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*cacheEntry[V]
+}
+
+type cacheEntry[V any] struct {
+	mu    sync.Mutex
+	value V
+	ok    bool
+}
+
+// get returns the value cached for key, calling fetch to produce it when
+// there is none.
+func (c *cache[K, V]) get(key K, fetch func() (V, error)) (V, error) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[K]*cacheEntry[V]{}
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry[V]{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.ok {
+		return entry.value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	entry.value, entry.ok = value, true
+
+	return value, nil
+}
@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultTopCoSpeakers is the number of co-speakers reported per character
+// when top_co_speakers is not set.
+const defaultTopCoSpeakers = 3
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &CharacterStatsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &CharacterStatsDataSource{}
+)
+
+func NewCharacterStatsDataSource() datasource.DataSource {
+	return &CharacterStatsDataSource{}
+}
+
+// CharacterStatsDataSource defines the data source implementation.
+type CharacterStatsDataSource struct {
+	providerData *providerData
+}
+
+// CharacterStatsDataSourceModel describes the data source data model.
+type CharacterStatsDataSourceModel struct {
+	Season            types.Int64           `tfsdk:"season"`
+	Episode           types.Int64           `tfsdk:"episode"`
+	Characters        []types.String        `tfsdk:"characters"`
+	ExcludeCharacters []types.String        `tfsdk:"exclude_characters"`
+	TopCoSpeakers     types.Int64           `tfsdk:"top_co_speakers"`
+	Statistics        []characterStatsModel `tfsdk:"statistics"`
+	ID                types.String          `tfsdk:"id"`
+}
+
+type characterStatsModel struct {
+	Character         types.String     `tfsdk:"character"`
+	LineCount         types.Int64      `tfsdk:"line_count"`
+	WordCount         types.Int64      `tfsdk:"word_count"`
+	AverageLineLength types.Float64    `tfsdk:"average_line_length"`
+	DialogueShare     types.Float64    `tfsdk:"dialogue_share"`
+	EpisodeCount      types.Int64      `tfsdk:"episode_count"`
+	TopCoSpeakers     []coSpeakerModel `tfsdk:"top_co_speakers"`
+}
+
+type coSpeakerModel struct {
+	Character    types.String `tfsdk:"character"`
+	SharedScenes types.Int64  `tfsdk:"shared_scenes"`
+}
+
+func (d *CharacterStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_character_stats"
+}
+
+func (d *CharacterStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Computes dialogue statistics per character",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"season": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number to compute statistics for. Defaults to the provider's default_season, or every season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"episode": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Episode number to compute statistics for. Requires season, or the provider's default_season.",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
+			},
+			"characters": charactersAttribute("statistics"),
+			"exclude_characters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Characters not to report statistics for, nor as co-speakers. Defaults to the provider's " +
+					"exclude_characters.",
+			},
+			"top_co_speakers": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of co-speakers to report for each character (default: %d)", defaultTopCoSpeakers),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"statistics": schema.ListNestedAttribute{
+				Description: "List of character statistics, ordered by line count",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"character": schema.StringAttribute{
+							Description: "The name of the character.",
+							Computed:    true,
+						},
+						"line_count": schema.Int64Attribute{
+							Description: "The number of lines the character speaks.",
+							Computed:    true,
+						},
+						"word_count": schema.Int64Attribute{
+							Description: "The number of words the character speaks.",
+							Computed:    true,
+						},
+						"average_line_length": schema.Float64Attribute{
+							Description: "The mean number of words per line.",
+							Computed:    true,
+						},
+						"dialogue_share": schema.Float64Attribute{
+							Description: "The fraction of all words spoken that the character speaks.",
+							Computed:    true,
+						},
+						"episode_count": schema.Int64Attribute{
+							Description: "The number of episodes the character speaks in.",
+							Computed:    true,
+						},
+						"top_co_speakers": schema.ListNestedAttribute{
+							Description: "The characters who share the most scenes with the character",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"character": schema.StringAttribute{
+										Description: "The name of the co-speaker.",
+										Computed:    true,
+									},
+									"shared_scenes": schema.Int64Attribute{
+										Description: "The number of scenes both characters speak in.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CharacterStatsDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
+	}
+}
+
+func (d *CharacterStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *CharacterStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CharacterStatsDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope := d.providerData.settings.characterScope(data.Characters, data.ExcludeCharacters)
+
+	// Read Terraform configuration data into the model
+	quotes, err := d.providerData.readQuotes(ctx, data.Season, data.Episode, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read theOffice Quotes",
+			err.Error(),
+		)
+		return
+	}
+
+	topCoSpeakers := defaultTopCoSpeakers
+	if !data.TopCoSpeakers.IsNull() {
+		topCoSpeakers = int(data.TopCoSpeakers.ValueInt64())
+	}
+
+	for _, stats := range theoffice.ComputeCharacterStats(quotes) {
+		if !scope.allows(stats.Character) {
+			continue
+		}
+
+		statsState := characterStatsModel{
+			Character:         types.StringValue(stats.Character),
+			LineCount:         types.Int64Value(int64(stats.LineCount)),
+			WordCount:         types.Int64Value(int64(stats.WordCount)),
+			AverageLineLength: types.Float64Value(stats.AverageLineLength),
+			DialogueShare:     types.Float64Value(stats.DialogueShare),
+			EpisodeCount:      types.Int64Value(int64(stats.Episodes)),
+			TopCoSpeakers:     []coSpeakerModel{},
+		}
+
+		for _, coSpeaker := range stats.CoSpeakers {
+			if len(statsState.TopCoSpeakers) == topCoSpeakers {
+				break
+			}
+			if scope.excludes(coSpeaker.Character) {
+				continue
+			}
+			statsState.TopCoSpeakers = append(statsState.TopCoSpeakers, coSpeakerModel{
+				Character:    types.StringValue(coSpeaker.Character),
+				SharedScenes: types.Int64Value(int64(coSpeaker.SharedScenes)),
+			})
+		}
+
+		data.Statistics = append(data.Statistics, statsState)
+	}
+
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read character stats data source")
+}
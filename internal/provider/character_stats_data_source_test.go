@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCharacterStatsDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCharacterStatsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.theoffice_character_stats.test", "statistics.#"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.episode_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCharacterStatsDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCharacterStatsDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.#", "7"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.character", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.line_count", "12"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.episode_count", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.top_co_speakers.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.top_co_speakers.0.character", "Dwight"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.top_co_speakers.0.shared_scenes", "3"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCharacterStatsDataSourceConfig = `
+data "theoffice_character_stats" "test" {
+  season  = 1
+  episode = 1
+}
+`
+
+func testAccCharacterStatsDataSourceConfig_stubServer(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_character_stats" "test" {
+  season          = 1
+  top_co_speakers = 1
+}
+`, endpoint)
+}
+
+func TestAccCharacterStatsDataSource_characters(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCharacterStatsDataSourceConfig_characters(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_character_stats.test", "statistics.0.character", "Dwight"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCharacterStatsDataSourceConfig_characters(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_character_stats" "test" {
+  season     = 1
+  characters = ["Dwight"]
+}
+`, endpoint)
+}
@@ -6,15 +6,37 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
+
 	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const (
+	// connectionsSourceAPI reads connections from the connections endpoint.
+	connectionsSourceAPI = "api"
+
+	// connectionsSourceDerived builds connections locally from quotes.
+	connectionsSourceDerived = "derived"
+
+	// connectionsSourceAuto reads connections from the connections endpoint,
+	// falling back to deriving them from quotes when the endpoint fails.
+	connectionsSourceAuto = "auto"
+)
+
 var (
-	_ datasource.DataSource = &ConnectionsDataSource{}
+	_ datasource.DataSource                     = &ConnectionsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ConnectionsDataSource{}
 )
 
 func NewConnectionsDataSource() datasource.DataSource {
@@ -22,13 +44,21 @@ func NewConnectionsDataSource() datasource.DataSource {
 }
 
 type ConnectionsDataSource struct {
-	client *theoffice.Client
+	providerData *providerData
 }
 
 type ConnectionsDataSourceModel struct {
-	Connections []connectionsModel `tfsdk:"connections"`
-	Season      types.Int64        `tfsdk:"season"`
-	ID          types.String       `tfsdk:"id"`
+	Connections          []connectionsModel          `tfsdk:"connections"`
+	ConnectionsByEpisode map[string]connectionsModel `tfsdk:"connections_by_episode"`
+	Season               types.Int64                 `tfsdk:"season"`
+	Characters           []types.String              `tfsdk:"characters"`
+	ExcludeCharacters    []types.String              `tfsdk:"exclude_characters"`
+	Source               types.String                `tfsdk:"source"`
+	Window               types.Int64                 `tfsdk:"window"`
+	Weighting            types.String                `tfsdk:"weighting"`
+	Algorithm            types.String                `tfsdk:"algorithm"`
+	Directed             types.Bool                  `tfsdk:"directed"`
+	ID                   types.String                `tfsdk:"id"`
 }
 
 type connectionsModel struct {
@@ -63,53 +93,112 @@ func (d *ConnectionsDataSource) Schema(ctx context.Context, req datasource.Schem
 				Computed:    true,
 			},
 			"season": schema.Int64Attribute{
-				Required:    true,
-				Description: "Season number to filter results by",
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number to filter results by. Required unless the provider sets default_season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"characters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Characters to report connections between. Links are only reported when both their source and " +
+					"target are selected. Defaults to the provider's default_characters, or every character.",
+			},
+			"exclude_characters": excludeCharactersAttribute("connections"),
+			"source": schema.StringAttribute{
+				Optional: true,
+				Description: "Where connections are read from: \"api\" (default) uses the connections endpoint, \"derived\" builds them " +
+					"from the season's quotes, and \"auto\" uses the endpoint but falls back to deriving them if it fails.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(connectionsSourceAPI, connectionsSourceDerived, connectionsSourceAuto),
+				},
+			},
+			"window": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("When deriving connections, the number of preceding lines in a scene that a line is considered "+
+					"to respond to (default: %d). Requires source to be \"derived\" or \"auto\".", theoffice.DefaultConnectionWindow),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"weighting": schema.StringAttribute{
+				Optional: true,
+				Description: "When deriving connections, the algorithm used to compute link values: \"adjacent\" (default) counts " +
+					"exchanges between lines in a scene, \"scene\" counts the scenes two characters both speak in, and \"mentions\" " +
+					"counts the lines in which a character names another. Requires source to be \"derived\" or \"auto\".",
+				Validators: []validator.String{
+					stringvalidator.OneOf(theoffice.Weightings...),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				Description: "The algorithm that produced the link values: \"api\" when connections were read from the API, " +
+					"otherwise the weighting used to derive them.",
+				Computed: true,
+			},
+			"directed": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Whether links keep their direction. When false, each link's source and target are ordered " +
+					"alphabetically and links between the same characters in either direction are merged. When set, self-loops " +
+					"are removed. When unset, links read from the API are kept as the API returns them, and derived links are undirected. Cannot be true when weighting is \"scene\".",
 			},
 			"connections": schema.ListNestedAttribute{
-				Description: "List of character connections",
+				Description:  "List of character connections",
+				Computed:     true,
+				NestedObject: connectionNestedObject(),
+			},
+			"connections_by_episode": schema.MapNestedAttribute{
+				Description: "The connections of the connections attribute, keyed by episode number. Keys don't change when " +
+					"episodes are added, so they are suitable for for_each.",
+				Computed:     true,
+				NestedObject: connectionNestedObject(),
+			},
+		},
+	}
+}
+
+// connectionNestedObject returns the schema of an episode's connections.
+func connectionNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"episode": schema.Int64Attribute{
+				Description: "The episode the connection, i.e. dialogue between characters, occurred in.",
+				Computed:    true,
+			},
+			"episode_name": schema.StringAttribute{
+				Description: "The name of the episode the connection, i.e. dialogue between characters, occurred in.",
+				Computed:    true,
+			},
+			"links": schema.ListNestedAttribute{
+				Description: "The list of links between characters",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"episode": schema.Int64Attribute{
-							Description: "The episode the connection, i.e. dialogue between characters, occurred in.",
+						"source": schema.StringAttribute{
+							Description: "The name of the source character.",
 							Computed:    true,
 						},
-						"episode_name": schema.StringAttribute{
-							Description: "The name of the episode the connection, i.e. dialogue between characters, occurred in.",
+						"target": schema.StringAttribute{
+							Description: "The name of the target character.",
 							Computed:    true,
 						},
-						"links": schema.ListNestedAttribute{
-							Description: "The list of links between characters",
+						"value": schema.Int64Attribute{
+							Description: "The value of the link.",
 							Computed:    true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"source": schema.StringAttribute{
-										Description: "The name of the source character.",
-										Computed:    true,
-									},
-									"target": schema.StringAttribute{
-										Description: "The name of the target character.",
-										Computed:    true,
-									},
-									"value": schema.Int64Attribute{
-										Description: "The value of the link.",
-										Computed:    true,
-									},
-								},
-							},
 						},
-						"nodes": schema.ListNestedAttribute{
-							Description: "The list of nodes i.e. characters in the episode",
+					},
+				},
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "The list of nodes i.e. characters in the episode",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The name of the target character.",
 							Computed:    true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"id": schema.StringAttribute{
-										Description: "The name of the target character.",
-										Computed:    true,
-									},
-								},
-							},
 						},
 					},
 				},
@@ -118,33 +207,49 @@ func (d *ConnectionsDataSource) Schema(ctx context.Context, req datasource.Schem
 	}
 }
 
-func (d *ConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*theoffice.Client)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *theoffice.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
-		return
+func (d *ConnectionsDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		// Connections are only derived with window and weighting when they
+		// aren't read from the API.
+		requiresOneOfValidator{
+			attributes: []path.Path{path.Root("window"), path.Root("weighting")},
+			required:   path.Root("source"),
+			values:     []string{connectionsSourceDerived, connectionsSourceAuto},
+		},
+		// Scene weighting links characters by the scenes they share, which
+		// has no direction.
+		trueConflictsWithValueValidator{
+			flag:        path.Root("directed"),
+			conflicting: path.Root("weighting"),
+			value:       theoffice.WeightingScene,
+		},
 	}
+}
 
-	d.client = client
+func (d *ConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
 }
 
 func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data ConnectionsDataSourceModel
 
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, types.Int64Null(), true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope := d.providerData.settings.characterScope(data.Characters, data.ExcludeCharacters)
 
 	// Read Terraform configuration data into the model
-	connResp, err := d.client.GetConnections(ctx, int(data.Season.ValueInt64()))
+	connections, algorithm, err := d.readConnections(ctx, &data, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read theOffice Connections",
@@ -153,13 +258,21 @@ func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	for _, conn := range connResp.Connections {
+	data.ConnectionsByEpisode = map[string]connectionsModel{}
+	for _, conn := range connections {
 		connectionsState := connectionsModel{
 			Episode:     types.Int64Value(int64(conn.Episode)),
 			EpisodeName: types.StringValue(conn.EpisodeName),
 		}
 
-		for _, link := range conn.Links {
+		links := conn.Links
+		if !data.Directed.IsNull() {
+			links = theoffice.NormalizeLinks(links, data.Directed.ValueBool())
+		}
+		for _, link := range links {
+			if !scope.allows(link.Source) || !scope.allows(link.Target) {
+				continue
+			}
 			connectionsState.Links = append(connectionsState.Links, connectionsLinkModel{
 				Source: types.StringValue(link.Source),
 				Target: types.StringValue(link.Target),
@@ -168,14 +281,22 @@ func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		}
 
 		for _, node := range conn.Nodes {
+			if !scope.allows(node.ID) {
+				continue
+			}
 			connectionsState.Nodes = append(connectionsState.Nodes, connectionsNodeModel{
 				ID: types.StringValue(node.ID),
 			})
 		}
 
 		data.Connections = append(data.Connections, connectionsState)
+		data.ConnectionsByEpisode[strconv.Itoa(conn.Episode)] = connectionsState
 	}
 
+	data.Algorithm = types.StringValue(algorithm)
+	if data.Directed.IsNull() && algorithm != connectionsSourceAPI {
+		data.Directed = types.BoolValue(false)
+	}
 	data.ID = types.StringValue("placeholder")
 
 	// Save data into Terraform state
@@ -187,3 +308,44 @@ func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	tflog.Trace(ctx, "read connections data source")
 }
+
+// readConnections returns the season's connections from the configured source,
+// along with the algorithm that produced their link values.
+func (d *ConnectionsDataSource) readConnections(ctx context.Context, data *ConnectionsDataSourceModel, diags *diag.Diagnostics) ([]theoffice.Connection, string, error) {
+	season := int(data.Season.ValueInt64())
+
+	source := connectionsSourceAPI
+	if !data.Source.IsNull() {
+		source = data.Source.ValueString()
+	}
+
+	if source != connectionsSourceDerived {
+		connections, err := d.providerData.getConnections(ctx, season, diags)
+		if err == nil || source == connectionsSourceAPI {
+			return connections, connectionsSourceAPI, err
+		}
+
+		tflog.Warn(ctx, "reading connections failed, deriving them from quotes", map[string]any{
+			"season": season,
+			"error":  err.Error(),
+		})
+	}
+
+	quotes, err := d.providerData.getQuotes(ctx, season, 0, diags)
+	if err != nil {
+		return nil, "", fmt.Errorf("deriving connections: %w", err)
+	}
+
+	weighting := theoffice.WeightingAdjacent
+	if !data.Weighting.IsNull() {
+		weighting = data.Weighting.ValueString()
+	}
+
+	connections, err := theoffice.BuildConnections(quotes, theoffice.ConnectionOptions{
+		Window:    int(data.Window.ValueInt64()),
+		Weighting: weighting,
+		Directed:  data.Directed.ValueBool(),
+	})
+
+	return connections, weighting, err
+}
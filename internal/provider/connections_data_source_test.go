@@ -4,14 +4,19 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccConnectionsDataSource(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
 		Steps: []resource.TestStep{
 			// Read testing
 			{
@@ -24,8 +29,376 @@ func TestAccConnectionsDataSource(t *testing.T) {
 	})
 }
 
+func TestAccConnectionsDataSource_derived(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_source(srv.URL, "derived"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.value", "4"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "algorithm", "adjacent"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_byEpisode(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_source(srv.URL, "derived"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections_by_episode.%", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections_by_episode.1.episode_name", "Pilot"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections_by_episode.1.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections_by_episode.2.episode_name", "Diversity Day"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_autoFallback(t *testing.T) {
+	srv := theofficetest.NewUnstartedServer()
+	handler := srv.Handler()
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/season/1/format/connections" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+	srv.Start()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_source(srv.URL, "auto"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.1.episode_name", "Diversity Day"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "algorithm", "adjacent"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_weighting(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_weighting(srv.URL, "scene"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "algorithm", "scene"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.value", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_weightingWithoutDerivedSource(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConnectionsDataSourceConfig_sourceWeighting(srv.URL, "api", "scene"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// source defaults to "api".
+			{
+				Config:      testAccConnectionsDataSourceConfig_sourceWeighting(srv.URL, "", "scene"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: testAccConnectionsDataSourceConfig_sourceWeighting(srv.URL, "auto", "scene"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "algorithm", "api"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_directed(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_directed(srv.URL, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "directed", "true"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.value", "2"),
+				),
+			},
+			{
+				Config: testAccConnectionsDataSourceConfig_directed(srv.URL, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "directed", "false"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.value", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_directedSceneWeighting(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConnectionsDataSourceConfig_weightingDirected(srv.URL, "scene", true),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: testAccConnectionsDataSourceConfig_weightingDirected(srv.URL, "scene", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "algorithm", "scene"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "directed", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_directedUnset(t *testing.T) {
+	dataset := theofficetest.DefaultDataset()
+	links := dataset.Connections[1][0].Links
+	links[0].Source, links[0].Target = links[0].Target, links[0].Source
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_source(srv.URL, "api"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.theoffice_connections.test", "directed"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Jim"),
+				),
+			},
+			{
+				Config: testAccConnectionsDataSourceConfig_sourceDirected(srv.URL, "api", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "directed", "false"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Michael"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_speakers(t *testing.T) {
+	dataset := theofficetest.DefaultDataset()
+	dataset.Connections[1][0].Links[0].Source = "Jim & Pam"
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Links read from the API are kept as served by default.
+			{
+				Config: testAccConnectionsDataSourceConfig_speakers(srv.URL, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.#", "4"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim & Pam"),
+				),
+			},
+			{
+				Config: testAccConnectionsDataSourceConfig_speakers(srv.URL, `speaker_separators = [" & "]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.#", "5"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.1.source", "Pam"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.1.target", "Michael"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionsDataSource_providerDefaults(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccConnectionsDataSourceConfig_providerDefaults(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "season", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.source", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.links.0.target", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_connections.test", "connections.0.nodes.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 const testAccConnectionsDataSourceConfig = `
 data "theoffice_connections" "test" {
   season = 1
 }
 `
+
+func testAccConnectionsDataSourceConfig_source(endpoint, source string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_connections" "test" {
+  season = 1
+  source = %[2]q
+}
+`, endpoint, source)
+}
+
+func testAccConnectionsDataSourceConfig_weighting(endpoint, weighting string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_connections" "test" {
+  season    = 1
+  source    = "derived"
+  weighting = %[2]q
+}
+`, endpoint, weighting)
+}
+
+func testAccConnectionsDataSourceConfig_sourceWeighting(endpoint, source, weighting string) string {
+	var sourceAttr string
+	if source != "" {
+		sourceAttr = fmt.Sprintf("source    = %q", source)
+	}
+
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_connections" "test" {
+  season    = 1
+  %[2]s
+  weighting = %[3]q
+}
+`, endpoint, sourceAttr, weighting)
+}
+
+func testAccConnectionsDataSourceConfig_weightingDirected(endpoint, weighting string, directed bool) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_connections" "test" {
+  season    = 1
+  source    = "derived"
+  weighting = %[2]q
+  directed  = %[3]t
+}
+`, endpoint, weighting, directed)
+}
+
+func testAccConnectionsDataSourceConfig_directed(endpoint string, directed bool) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_connections" "test" {
+  season   = 1
+  source   = "derived"
+  directed = %[2]t
+}
+`, endpoint, directed)
+}
+
+func testAccConnectionsDataSourceConfig_sourceDirected(endpoint, source string, directed bool) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_connections" "test" {
+  season   = 1
+  source   = %[2]q
+  directed = %[3]t
+}
+`, endpoint, source, directed)
+}
+
+func testAccConnectionsDataSourceConfig_providerDefaults(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint           = %[1]q
+  default_season     = 1
+  default_characters = ["Michael", "Jim"]
+}
+
+data "theoffice_connections" "test" {}
+`, endpoint)
+}
+
+func testAccConnectionsDataSourceConfig_speakers(endpoint, extra string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+  %[2]s
+}
+
+data "theoffice_connections" "test" {
+  season = 1
+}
+`, endpoint, extra)
+}
@@ -0,0 +1,427 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &DatasetDiffDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &DatasetDiffDataSource{}
+)
+
+func NewDatasetDiffDataSource() datasource.DataSource {
+	return &DatasetDiffDataSource{}
+}
+
+// DatasetDiffDataSource defines the data source implementation.
+type DatasetDiffDataSource struct {
+	providerData *providerData
+}
+
+// DatasetDiffDataSourceModel describes the data source data model.
+type DatasetDiffDataSourceModel struct {
+	BaseEndpoint    types.String       `tfsdk:"base_endpoint"`
+	BaseSnapshot    types.Bool         `tfsdk:"base_snapshot"`
+	CompareEndpoint types.String       `tfsdk:"compare_endpoint"`
+	Seasons         []types.Int64      `tfsdk:"seasons"`
+	InSync          types.Bool         `tfsdk:"in_sync"`
+	QuoteChanges    []quoteChangeModel `tfsdk:"quote_changes"`
+	LinkChanges     []linkChangeModel  `tfsdk:"link_changes"`
+	ID              types.String       `tfsdk:"id"`
+}
+
+type quoteChangeModel struct {
+	Change             types.String `tfsdk:"change"`
+	Key                types.String `tfsdk:"key"`
+	Season             types.Int64  `tfsdk:"season"`
+	Episode            types.Int64  `tfsdk:"episode"`
+	Scene              types.Int64  `tfsdk:"scene"`
+	Line               types.Int64  `tfsdk:"line"`
+	BaseEpisodeName    types.String `tfsdk:"base_episode_name"`
+	BaseCharacter      types.String `tfsdk:"base_character"`
+	BaseQuote          types.String `tfsdk:"base_quote"`
+	CompareEpisodeName types.String `tfsdk:"compare_episode_name"`
+	CompareCharacter   types.String `tfsdk:"compare_character"`
+	CompareQuote       types.String `tfsdk:"compare_quote"`
+}
+
+type linkChangeModel struct {
+	Change       types.String `tfsdk:"change"`
+	Season       types.Int64  `tfsdk:"season"`
+	Episode      types.Int64  `tfsdk:"episode"`
+	Source       types.String `tfsdk:"source"`
+	Target       types.String `tfsdk:"target"`
+	BaseValue    types.Int64  `tfsdk:"base_value"`
+	CompareValue types.Int64  `tfsdk:"compare_value"`
+}
+
+func (d *DatasetDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_diff"
+}
+
+func (d *DatasetDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	changes := strings.Join([]string{theoffice.ChangeAdded, theoffice.ChangeRemoved, theoffice.ChangeChanged}, "\", \"")
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Compares the quotes and connections served by two endpoints, such as a mirror and the API it " +
+			"mirrors, or by an endpoint and the snapshot of the API bundled with the provider, and reports the quotes and " +
+			"links added, removed or changed in the compared endpoint. Quotes are identified by their position in the " +
+			"show, so a line inserted in a scene also changes the lines after it. Quotes and links are compared as served, " +
+			"without the provider's character_aliases or speaker parsing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"base_endpoint": schema.StringAttribute{
+				Optional: true,
+				Description: "The REST API endpoint to compare against. Defaults to the provider's first endpoint, which is read " +
+					"without failing over to the others, so that a mirror isn't compared with itself.",
+			},
+			"base_snapshot": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to compare against the snapshot of the API bundled with the provider, instead of an " +
+					"endpoint. The snapshot is taken from the API the provider reads by default.",
+			},
+			"compare_endpoint": schema.StringAttribute{
+				Required:    true,
+				Description: "The REST API endpoint to compare to the base endpoint.",
+			},
+			"seasons": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "Season numbers to compare. Defaults to the provider's default_season, or every season.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueInt64sAre(seasonValidator()),
+				},
+			},
+			"in_sync": schema.BoolAttribute{
+				Description: "Whether both endpoints serve the same quotes and connections.",
+				Computed:    true,
+			},
+			"quote_changes": schema.ListNestedAttribute{
+				Description: "The quotes that differ, ordered by their position in the show.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"change": schema.StringAttribute{
+							Description: fmt.Sprintf("How the quote differs in the compared endpoint: \"%s\".", changes),
+							Computed:    true,
+						},
+						"key": schema.StringAttribute{
+							Description: "The key of the quote, as in theoffice_quotes' quotes_by_key.",
+							Computed:    true,
+						},
+						"season": schema.Int64Attribute{
+							Description: "The season the quote occurred in.",
+							Computed:    true,
+						},
+						"episode": schema.Int64Attribute{
+							Description: "The episode the quote occurred in.",
+							Computed:    true,
+						},
+						"scene": schema.Int64Attribute{
+							Description: "The scene the quote occurred in.",
+							Computed:    true,
+						},
+						"line": schema.Int64Attribute{
+							Description: "The position of the quote in its scene, from 1.",
+							Computed:    true,
+						},
+						"base_episode_name": schema.StringAttribute{
+							Description: "The name of the episode served by the base endpoint. Null when the quote was added.",
+							Computed:    true,
+						},
+						"base_character": schema.StringAttribute{
+							Description: "The character who said the quote, served by the base endpoint. Null when the quote was added.",
+							Computed:    true,
+						},
+						"base_quote": schema.StringAttribute{
+							Description: "The quote served by the base endpoint. Null when the quote was added.",
+							Computed:    true,
+						},
+						"compare_episode_name": schema.StringAttribute{
+							Description: "The name of the episode served by the compared endpoint. Null when the quote was removed.",
+							Computed:    true,
+						},
+						"compare_character": schema.StringAttribute{
+							Description: "The character who said the quote, served by the compared endpoint. Null when the quote was removed.",
+							Computed:    true,
+						},
+						"compare_quote": schema.StringAttribute{
+							Description: "The quote served by the compared endpoint. Null when the quote was removed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"link_changes": schema.ListNestedAttribute{
+				Description: "The connection links that differ, ordered by season, episode, source and target.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"change": schema.StringAttribute{
+							Description: fmt.Sprintf("How the link differs in the compared endpoint: \"%s\".", changes),
+							Computed:    true,
+						},
+						"season": schema.Int64Attribute{
+							Description: "The season of the link.",
+							Computed:    true,
+						},
+						"episode": schema.Int64Attribute{
+							Description: "The episode of the link.",
+							Computed:    true,
+						},
+						"source": schema.StringAttribute{
+							Description: "The source character of the link.",
+							Computed:    true,
+						},
+						"target": schema.StringAttribute{
+							Description: "The target character of the link.",
+							Computed:    true,
+						},
+						"base_value": schema.Int64Attribute{
+							Description: "The value of the link served by the base endpoint. Null when the link was added.",
+							Computed:    true,
+						},
+						"compare_value": schema.Int64Attribute{
+							Description: "The value of the link served by the compared endpoint. Null when the link was removed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DatasetDiffDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		trueConflictsWithValidator{flag: path.Root("base_snapshot"), conflicting: path.Root("base_endpoint")},
+	}
+}
+
+func (d *DatasetDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *DatasetDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DatasetDiffDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read Terraform configuration data into the model. The base is read
+	// without failover, so that a mirror isn't compared with itself while the
+	// endpoint it mirrors is down, and without the cache, so that reads of other
+	// data sources aren't served by it.
+	var base func(context.Context, int, *diag.Diagnostics) ([]theoffice.Quote, []theoffice.Connection, error)
+	baseEndpoint := d.providerData.client.Addresses()[0]
+	if !data.BaseEndpoint.IsNull() {
+		baseEndpoint = data.BaseEndpoint.ValueString()
+	}
+	if data.BaseSnapshot.ValueBool() {
+		snapshot, err := theoffice.BundledSnapshot()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read theOffice Snapshot", err.Error())
+			return
+		}
+		baseEndpoint = "the bundled snapshot"
+		base = d.snapshotDataset(snapshot)
+	} else {
+		client, err := d.providerData.newClient(baseEndpoint)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid theOffice API Endpoint", err.Error())
+			return
+		}
+		base = d.endpointDataset(client)
+	}
+
+	client, err := d.providerData.newClient(data.CompareEndpoint.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid theOffice API Endpoint", err.Error())
+		return
+	}
+	compare := d.endpointDataset(client)
+
+	data.QuoteChanges = []quoteChangeModel{}
+	data.LinkChanges = []linkChangeModel{}
+	for _, season := range d.seasons(data.Seasons) {
+		baseQuotes, baseConnections, err := base(ctx, season, &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read theOffice Dataset",
+				fmt.Sprintf("Reading season %d from %s: %s", season, baseEndpoint, err),
+			)
+			return
+		}
+		compareQuotes, compareConnections, err := compare(ctx, season, &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read theOffice Dataset",
+				fmt.Sprintf("Reading season %d from %s: %s", season, data.CompareEndpoint.ValueString(), err),
+			)
+			return
+		}
+
+		for _, diff := range theoffice.DiffQuotes(baseQuotes, compareQuotes) {
+			data.QuoteChanges = append(data.QuoteChanges, quoteChangeState(diff))
+		}
+		for _, diff := range theoffice.DiffConnections(season, baseConnections, compareConnections) {
+			data.LinkChanges = append(data.LinkChanges, linkChangeState(diff))
+		}
+	}
+
+	data.InSync = types.BoolValue(len(data.QuoteChanges) == 0 && len(data.LinkChanges) == 0)
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read dataset diff data source")
+}
+
+// seasons returns the configured seasons, the provider's default_season, or
+// every season.
+func (d *DatasetDiffDataSource) seasons(configured []types.Int64) []int {
+	var seasons []int
+	switch {
+	case configured != nil:
+		for _, s := range configured {
+			seasons = append(seasons, int(s.ValueInt64()))
+		}
+	case !d.providerData.settings.defaultSeason.IsNull():
+		seasons = append(seasons, int(d.providerData.settings.defaultSeason.ValueInt64()))
+	default:
+		for s := 1; s <= theoffice.NumSeasons; s++ {
+			seasons = append(seasons, s)
+		}
+	}
+
+	return seasons
+}
+
+// endpointDataset returns a function reading a season's quotes and
+// connections through client. They are compared as the endpoint serves them,
+// without normalizing character names, so that a renamed character or a
+// changed speaker separator is reported as drift.
+func (d *DatasetDiffDataSource) endpointDataset(client *theoffice.Client) func(context.Context, int, *diag.Diagnostics) ([]theoffice.Quote, []theoffice.Connection, error) {
+	return func(ctx context.Context, season int, diags *diag.Diagnostics) ([]theoffice.Quote, []theoffice.Connection, error) {
+		var quotes *theoffice.QuotesResponse
+		var connections *theoffice.ConnectionsResponse
+		err := d.providerData.limit(ctx, func() (err error) {
+			quotes, err = client.GetQuotes(ctx, season, 0)
+			return err
+		})
+		if quotes == nil {
+			return nil, nil, err
+		}
+		checkedQuotes, err := check(quotes.Quotes, err, d.providerData.strictDecoding)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = d.providerData.limit(ctx, func() (err error) {
+			connections, err = client.GetConnections(ctx, season)
+			return err
+		})
+		if connections == nil {
+			return nil, nil, err
+		}
+		checkedConnections, err := check(connections.Connections, err, d.providerData.strictDecoding)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return checkedQuotes.warn(diags), checkedConnections.warn(diags), nil
+	}
+}
+
+// snapshotDataset returns a function reading a season's quotes and
+// connections from snapshot.
+func (d *DatasetDiffDataSource) snapshotDataset(snapshot *theoffice.Snapshot) func(context.Context, int, *diag.Diagnostics) ([]theoffice.Quote, []theoffice.Connection, error) {
+	return func(ctx context.Context, season int, diags *diag.Diagnostics) ([]theoffice.Quote, []theoffice.Connection, error) {
+		return snapshot.SeasonQuotes(season), snapshot.SeasonConnections(season), nil
+	}
+}
+
+func quoteChangeState(diff theoffice.QuoteDiff) quoteChangeModel {
+	state := quoteChangeModel{
+		Change:             types.StringValue(diff.Change),
+		Key:                types.StringValue(diff.Key),
+		Season:             types.Int64Value(int64(diff.Season)),
+		Episode:            types.Int64Value(int64(diff.Episode)),
+		Scene:              types.Int64Value(int64(diff.Scene)),
+		Line:               types.Int64Value(int64(diff.Line)),
+		BaseEpisodeName:    types.StringNull(),
+		BaseCharacter:      types.StringNull(),
+		BaseQuote:          types.StringNull(),
+		CompareEpisodeName: types.StringNull(),
+		CompareCharacter:   types.StringNull(),
+		CompareQuote:       types.StringNull(),
+	}
+	if diff.Base != nil {
+		state.BaseEpisodeName = types.StringValue(diff.Base.EpisodeName)
+		state.BaseCharacter = types.StringValue(diff.Base.Character)
+		state.BaseQuote = types.StringValue(diff.Base.Quote)
+	}
+	if diff.Compare != nil {
+		state.CompareEpisodeName = types.StringValue(diff.Compare.EpisodeName)
+		state.CompareCharacter = types.StringValue(diff.Compare.Character)
+		state.CompareQuote = types.StringValue(diff.Compare.Quote)
+	}
+
+	return state
+}
+
+func linkChangeState(diff theoffice.LinkDiff) linkChangeModel {
+	state := linkChangeModel{
+		Change:       types.StringValue(diff.Change),
+		Season:       types.Int64Value(int64(diff.Season)),
+		Episode:      types.Int64Value(int64(diff.Episode)),
+		Source:       types.StringValue(diff.Source),
+		Target:       types.StringValue(diff.Target),
+		BaseValue:    types.Int64Null(),
+		CompareValue: types.Int64Null(),
+	}
+	if diff.Change != theoffice.ChangeAdded {
+		state.BaseValue = types.Int64Value(int64(diff.BaseValue))
+	}
+	if diff.Change != theoffice.ChangeRemoved {
+		state.CompareValue = types.Int64Value(int64(diff.CompareValue))
+	}
+
+	return state
+}
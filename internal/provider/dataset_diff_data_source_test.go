@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatasetDiffDataSource_inSync(t *testing.T) {
+	upstream := theofficetest.NewServer()
+	defer upstream.Close()
+	mirror := theofficetest.NewServer()
+	defer mirror.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDatasetDiffDataSourceConfig(upstream.URL, mirror.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "in_sync", "true"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.#", "0"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "link_changes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatasetDiffDataSource_drift(t *testing.T) {
+	upstream := theofficetest.NewServer()
+	defer upstream.Close()
+
+	dataset := theofficetest.DefaultDataset()
+	dataset.Quotes[0].Quote = "All right Jim. Your quarterlies look very good."
+	dataset.Quotes = append(dataset.Quotes, theoffice.Quote{
+		Season: 2, Episode: 1, Scene: 9, EpisodeName: "The Dundies", Character: "Toby", Quote: "Hi.",
+	})
+	dataset.Connections[1][0].Links[0].Value++
+	mirror := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer mirror.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDatasetDiffDataSourceConfig(upstream.URL, mirror.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "in_sync", "false"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.0.change", "changed"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.0.key", "s1e1sc1-1"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.0.compare_quote", "All right Jim. Your quarterlies look very good."),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.1.change", "added"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.1.season", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.1.scene", "9"),
+					resource.TestCheckNoResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.1.base_quote"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "link_changes.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "link_changes.0.change", "changed"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "link_changes.0.base_value", "4"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "link_changes.0.compare_value", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatasetDiffDataSource_renamedCharacter(t *testing.T) {
+	upstream := theofficetest.NewServer()
+	defer upstream.Close()
+
+	dataset := theofficetest.DefaultDataset()
+	dataset.Quotes[0].Character = "Michael Scott"
+	dataset.Connections[1][0].Links[0].Target = "Michael Scott"
+	mirror := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer mirror.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Character names are compared as served, even when the provider
+			// would merge them.
+			{
+				Config: testAccDatasetDiffDataSourceConfig(upstream.URL, mirror.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "in_sync", "false"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.0.base_character", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "quote_changes.0.compare_character", "Michael Scott"),
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "link_changes.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatasetDiffDataSource_baseDown(t *testing.T) {
+	down := theofficetest.NewServer()
+	down.Close()
+	mirror := theofficetest.NewServer()
+	defer mirror.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The mirror the provider fails over to isn't compared with itself.
+			{
+				Config:      testAccDatasetDiffDataSourceConfig_endpoints(down.URL, mirror.URL),
+				ExpectError: regexp.MustCompile(`Unable to Read theOffice Dataset`),
+			},
+		},
+	})
+}
+
+func TestAccDatasetDiffDataSource_baseSnapshotConflict(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDatasetDiffDataSourceConfig_baseSnapshot(srv.URL, true),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// base_endpoint is compared against when base_snapshot is false.
+			{
+				Config: testAccDatasetDiffDataSourceConfig_baseSnapshot(srv.URL, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_dataset_diff.test", "in_sync", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasetDiffDataSourceConfig(base, compare string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_dataset_diff" "test" {
+  compare_endpoint = %[2]q
+  seasons          = [1, 2]
+}
+`, base, compare)
+}
+
+func testAccDatasetDiffDataSourceConfig_endpoints(primary, mirror string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoints = [%[1]q, %[2]q]
+}
+
+data "theoffice_dataset_diff" "test" {
+  compare_endpoint = %[2]q
+  seasons          = [1]
+}
+`, primary, mirror)
+}
+
+func testAccDatasetDiffDataSourceConfig_baseSnapshot(endpoint string, baseSnapshot bool) string {
+	return fmt.Sprintf(`
+data "theoffice_dataset_diff" "test" {
+  base_endpoint    = %[1]q
+  base_snapshot    = %[2]t
+  compare_endpoint = %[1]q
+  seasons          = [1]
+}
+`, endpoint, baseSnapshot)
+}
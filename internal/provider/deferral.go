@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deferUnknownRead handles data source configurations holding values that
+// aren't known yet, such as a season computed from a resource that hasn't been
+// created. Reading them would query zero values instead, such as season 0, so
+// it returns true when the read must stop: the read is deferred when Terraform
+// supports deferred actions, and fails otherwise.
+//
+// It must be called before the configuration is read into a model, as models
+// holding Go slices or structs can't represent unknown values.
+func deferUnknownRead(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) bool {
+	if req.Config.Raw.IsFullyKnown() {
+		return false
+	}
+
+	if req.ClientCapabilities.DeferralAllowed {
+		tflog.Debug(ctx, "deferring data source read with unknown configuration values")
+		resp.Deferred = &datasource.Deferred{
+			Reason: datasource.DeferredReasonDataSourceConfigUnknown,
+		}
+		return true
+	}
+
+	resp.Diagnostics.AddError(
+		"Unknown theOffice Data Source Configuration",
+		"The data source cannot be read as its configuration holds values that are not known yet. "+
+			"Either target apply the source of the values first, or set the values statically in the configuration.",
+	)
+
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testReadWithUnknownValue reads the data source, which has no client
+// configured, with an unknown season, or an unknown value of its first
+// required attribute when it has no season, and every other attribute null.
+func testReadWithUnknownValue(t *testing.T, d datasource.DataSource, deferralAllowed bool) *datasource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	unknown := "season"
+	if _, ok := values[unknown]; !ok {
+		names := slices.Sorted(maps.Keys(schemaResp.Schema.Attributes))
+		i := slices.IndexFunc(names, func(name string) bool {
+			return schemaResp.Schema.Attributes[name].IsRequired()
+		})
+		if i < 0 {
+			t.Fatalf("%T: no season or required attribute to make unknown", d)
+		}
+		unknown = names[i]
+	}
+	values[unknown] = tftypes.NewValue(objectType.AttributeTypes[unknown], tftypes.UnknownValue)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		},
+		ClientCapabilities: datasource.ReadClientCapabilities{
+			DeferralAllowed: deferralAllowed,
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	d.Read(ctx, req, resp)
+
+	return resp
+}
+
+func TestDataSourceRead_deferUnknown(t *testing.T) {
+	for _, newDataSource := range (&theOfficeProvider{}).DataSources(context.Background()) {
+		d := newDataSource()
+
+		resp := testReadWithUnknownValue(t, d, true)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%T: unexpected diagnostics: %v", d, resp.Diagnostics)
+		}
+		if resp.Deferred == nil || resp.Deferred.Reason != datasource.DeferredReasonDataSourceConfigUnknown {
+			t.Errorf("%T: expected read to be deferred, got: %v", d, resp.Deferred)
+		}
+	}
+}
+
+func TestDataSourceRead_unknownWithoutDeferral(t *testing.T) {
+	for _, newDataSource := range (&theOfficeProvider{}).DataSources(context.Background()) {
+		d := newDataSource()
+
+		resp := testReadWithUnknownValue(t, d, false)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%T: expected an error diagnostic", d)
+		}
+		if resp.Deferred != nil {
+			t.Errorf("%T: unexpected deferral: %v", d, resp.Deferred)
+		}
+	}
+}
+
+func TestProviderConfigure_unknownWithoutDeferral(t *testing.T) {
+	ctx := context.Background()
+	p := &theOfficeProvider{}
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	for _, unknown := range []string{"default_characters", "exclude_characters", "character_aliases", "speaker_separators", "speaker_groups"} {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values[unknown] = tftypes.NewValue(objectType.AttributeTypes[unknown], tftypes.UnknownValue)
+
+		req := provider.ConfigureRequest{
+			Config: tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, values),
+			},
+		}
+		resp := &provider.ConfigureResponse{}
+		p.Configure(ctx, req, resp)
+
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Fatalf("%s: expected one error diagnostic, got: %v", unknown, resp.Diagnostics)
+		}
+		if !resp.Diagnostics.Contains(diag.NewAttributeErrorDiagnostic(
+			path.Root(unknown),
+			"Unknown theOffice Provider Setting",
+			"The provider cannot be configured as there is an unknown configuration value for "+unknown+". "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)) {
+			t.Errorf("%s: unexpected diagnostics: %v", unknown, resp.Diagnostics)
+		}
+	}
+}
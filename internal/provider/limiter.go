@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+)
+
+// defaultMaxConcurrentRequests is the number of API requests the provider
+// makes at once across all data sources.
+const defaultMaxConcurrentRequests = 4
+
+// limiter bounds the number of API requests in flight across every data
+// source of a provider.
+type limiter chan struct{}
+
+func newLimiter(n int) limiter {
+	return make(limiter, n)
+}
+
+// acquire waits for a free slot, or for ctx to be done. Callers that acquire
+// a slot must release it.
+func (l limiter) acquire(ctx context.Context) error {
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l limiter) release() {
+	<-l
+}
@@ -0,0 +1,286 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &PhraseStatsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &PhraseStatsDataSource{}
+)
+
+func NewPhraseStatsDataSource() datasource.DataSource {
+	return &PhraseStatsDataSource{}
+}
+
+// PhraseStatsDataSource defines the data source implementation.
+type PhraseStatsDataSource struct {
+	providerData *providerData
+}
+
+// PhraseStatsDataSourceModel describes the data source data model.
+type PhraseStatsDataSourceModel struct {
+	Season                 types.Int64             `tfsdk:"season"`
+	Episode                types.Int64             `tfsdk:"episode"`
+	Characters             []types.String          `tfsdk:"characters"`
+	ExcludeCharacters      []types.String          `tfsdk:"exclude_characters"`
+	RemoveStopwords        types.Bool              `tfsdk:"remove_stopwords"`
+	Limit                  types.Int64             `tfsdk:"limit"`
+	MinCatchphraseEpisodes types.Int64             `tfsdk:"min_catchphrase_episodes"`
+	Unigrams               []phraseCountModel      `tfsdk:"unigrams"`
+	Bigrams                []phraseCountModel      `tfsdk:"bigrams"`
+	Trigrams               []phraseCountModel      `tfsdk:"trigrams"`
+	ByCharacter            []characterPhrasesModel `tfsdk:"by_character"`
+	Catchphrases           []catchphraseModel      `tfsdk:"catchphrases"`
+	ID                     types.String            `tfsdk:"id"`
+}
+
+type phraseCountModel struct {
+	Phrase types.String `tfsdk:"phrase"`
+	Count  types.Int64  `tfsdk:"count"`
+}
+
+type characterPhrasesModel struct {
+	Character types.String       `tfsdk:"character"`
+	Unigrams  []phraseCountModel `tfsdk:"unigrams"`
+	Bigrams   []phraseCountModel `tfsdk:"bigrams"`
+	Trigrams  []phraseCountModel `tfsdk:"trigrams"`
+}
+
+type catchphraseModel struct {
+	Character    types.String `tfsdk:"character"`
+	Phrase       types.String `tfsdk:"phrase"`
+	EpisodeCount types.Int64  `tfsdk:"episode_count"`
+	Count        types.Int64  `tfsdk:"count"`
+}
+
+func (d *PhraseStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_phrase_stats"
+}
+
+// phraseCountsAttribute returns the schema of a list of phrases and their
+// number of occurrences.
+func phraseCountsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: description,
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"phrase": schema.StringAttribute{
+					Description: "The phrase, in lowercase.",
+					Computed:    true,
+				},
+				"count": schema.Int64Attribute{
+					Description: "The number of times the phrase is said.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func (d *PhraseStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Computes the most used words and phrases, and detects catchphrases",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"season": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number to analyze. Defaults to the provider's default_season, or every season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"episode": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Episode number to analyze. Requires season, or the provider's default_season.",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
+			},
+			"characters":         charactersAttribute("by_character phrases and catchphrases"),
+			"exclude_characters": excludeCharactersAttribute("by_character phrases and catchphrases"),
+			"remove_stopwords": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to ignore common words such as \"the\" and \"you\": stopwords are dropped as words, and " +
+					"phrases starting or ending with one are dropped (default: true)",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of phrases to report per phrase length (default: %d)", theoffice.DefaultPhraseLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_catchphrase_episodes": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("The number of distinct episodes a character must say a phrase in for it to be a catchphrase "+
+					"(default: %d)", theoffice.DefaultCatchphraseEpisodes),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"unigrams": phraseCountsAttribute("The most used words"),
+			"bigrams":  phraseCountsAttribute("The most used two-word phrases"),
+			"trigrams": phraseCountsAttribute("The most used three-word phrases"),
+			"by_character": schema.ListNestedAttribute{
+				Description: "The most used words and phrases of each character, ordered by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"character": schema.StringAttribute{
+							Description: "The name of the character.",
+							Computed:    true,
+						},
+						"unigrams": phraseCountsAttribute("The character's most used words"),
+						"bigrams":  phraseCountsAttribute("The character's most used two-word phrases"),
+						"trigrams": phraseCountsAttribute("The character's most used three-word phrases"),
+					},
+				},
+			},
+			"catchphrases": schema.ListNestedAttribute{
+				Description: "Phrases of up to five words that a character repeats across episodes, ordered by episode count",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"character": schema.StringAttribute{
+							Description: "The character who says the phrase.",
+							Computed:    true,
+						},
+						"phrase": schema.StringAttribute{
+							Description: "The phrase, in lowercase.",
+							Computed:    true,
+						},
+						"episode_count": schema.Int64Attribute{
+							Description: "The number of episodes the character says the phrase in.",
+							Computed:    true,
+						},
+						"count": schema.Int64Attribute{
+							Description: "The number of lines the character says the phrase in.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PhraseStatsDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
+	}
+}
+
+func (d *PhraseStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *PhraseStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PhraseStatsDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope := d.providerData.settings.characterScope(data.Characters, data.ExcludeCharacters)
+
+	// Read Terraform configuration data into the model
+	quotes, err := d.providerData.readQuotes(ctx, data.Season, data.Episode, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read theOffice Quotes",
+			err.Error(),
+		)
+		return
+	}
+
+	overall, perCharacter := theoffice.ComputePhraseStats(quotes, theoffice.PhraseOptions{
+		RemoveStopwords: data.RemoveStopwords.IsNull() || data.RemoveStopwords.ValueBool(),
+		Limit:           int(data.Limit.ValueInt64()),
+	})
+
+	data.Unigrams = phraseCountModels(overall.Unigrams)
+	data.Bigrams = phraseCountModels(overall.Bigrams)
+	data.Trigrams = phraseCountModels(overall.Trigrams)
+
+	data.ByCharacter = []characterPhrasesModel{}
+	for _, stats := range perCharacter {
+		if !scope.allows(stats.Character) {
+			continue
+		}
+		data.ByCharacter = append(data.ByCharacter, characterPhrasesModel{
+			Character: types.StringValue(stats.Character),
+			Unigrams:  phraseCountModels(stats.Unigrams),
+			Bigrams:   phraseCountModels(stats.Bigrams),
+			Trigrams:  phraseCountModels(stats.Trigrams),
+		})
+	}
+
+	data.Catchphrases = []catchphraseModel{}
+	for _, c := range theoffice.FindCatchphrases(quotes, int(data.MinCatchphraseEpisodes.ValueInt64())) {
+		if !scope.allows(c.Character) {
+			continue
+		}
+		data.Catchphrases = append(data.Catchphrases, catchphraseModel{
+			Character:    types.StringValue(c.Character),
+			Phrase:       types.StringValue(c.Phrase),
+			EpisodeCount: types.Int64Value(int64(c.Episodes)),
+			Count:        types.Int64Value(int64(c.Count)),
+		})
+	}
+
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read phrase stats data source")
+}
+
+func phraseCountModels(phrases []theoffice.PhraseCount) []phraseCountModel {
+	models := []phraseCountModel{}
+	for _, p := range phrases {
+		models = append(models, phraseCountModel{
+			Phrase: types.StringValue(p.Phrase),
+			Count:  types.Int64Value(int64(p.Count)),
+		})
+	}
+
+	return models
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPhraseStatsDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPhraseStatsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "unigrams.#", "5"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "by_character.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "by_character.0.character", "Dwight"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPhraseStatsDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPhraseStatsDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "unigrams.#", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "unigrams.0.phrase", "whassup"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "by_character.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "catchphrases.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "catchphrases.0.character", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "catchphrases.0.phrase", "that's what she said"),
+					resource.TestCheckResourceAttr("data.theoffice_phrase_stats.test", "catchphrases.0.episode_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccPhraseStatsDataSourceConfig = `
+data "theoffice_phrase_stats" "test" {
+  season     = 1
+  characters = ["Dwight"]
+  limit      = 5
+}
+`
+
+func testAccPhraseStatsDataSourceConfig_stubServer(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_phrase_stats" "test" {
+  season     = 1
+  characters = ["Michael"]
+  limit      = 3
+}
+`, endpoint)
+}
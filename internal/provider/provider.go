@@ -5,15 +5,24 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -28,11 +37,24 @@ type theOfficeProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// transport, when set, is used by theOffice client to reach the API.
+	// Acceptance tests use it to record and replay API interactions.
+	transport http.RoundTripper
 }
 
 // theOfficeProviderModel describes the provider data model.
 type theOfficeProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
+	Endpoint                types.String `tfsdk:"endpoint"`
+	Endpoints               types.List   `tfsdk:"endpoints"`
+	DefaultSeason           types.Int64  `tfsdk:"default_season"`
+	DefaultCharacters       types.List   `tfsdk:"default_characters"`
+	ExcludeCharacters       types.List   `tfsdk:"exclude_characters"`
+	StrictDecoding          types.String `tfsdk:"strict_decoding"`
+	CharacterAliases        types.Map    `tfsdk:"character_aliases"`
+	BuiltinCharacterAliases types.Bool   `tfsdk:"builtin_character_aliases"`
+	SpeakerSeparators       types.List   `tfsdk:"speaker_separators"`
+	SpeakerGroups           types.Map    `tfsdk:"speaker_groups"`
 }
 
 func (p *theOfficeProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,6 +69,88 @@ func (p *theOfficeProvider) Schema(ctx context.Context, req provider.SchemaReque
 			"endpoint": schema.StringAttribute{
 				Description: "The REST API endpoint to use for reading data (default: https://the-office.fly.dev)",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("endpoints")),
+				},
+			},
+			"endpoints": schema.ListAttribute{
+				Description: "REST API endpoints to use for reading data, in order of preference. Requests go to the first " +
+					"healthy endpoint, and fail over to the next one on connection errors and server errors. Endpoints that " +
+					"fail are probed again after 30 seconds, unless every endpoint failed, in which case the one that failed " +
+					"least recently is tried.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"default_season": schema.Int64Attribute{
+				Description: "The season data sources read when their season argument is unset. Data sources that otherwise " +
+					"read every season only read this one.",
+				Optional: true,
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"default_characters": schema.ListAttribute{
+				Description: "The characters data sources report on when their characters argument is unset. Every character " +
+					"is reported when unset.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"exclude_characters": schema.ListAttribute{
+				Description: "The characters data sources leave out when their exclude_characters argument is unset.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"character_aliases": schema.MapAttribute{
+				Description: "Maps names of characters to the name to use instead, such as to merge a character the API " +
+					"spells several ways. Names are matched ignoring case and extra whitespace, and are applied after the " +
+					"built-in aliases when builtin_character_aliases is set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"builtin_character_aliases": schema.BoolAttribute{
+				Description: "Whether to normalize character names with the built-in alias table, which maps full names and " +
+					"misspellings to the first name the API uses most, such as \"Michael Scott\" to \"Michael\". Names are " +
+					"left as the API returns them when unset, unless character_aliases renames them.",
+				Optional: true,
+			},
+			"speaker_separators": schema.ListAttribute{
+				Description: fmt.Sprintf("The separators splitting characters who say a line together, such as \"Jim & Pam\", "+
+					"into each speaker (default: %s). Listing \" and \" or \", \" too splits lines such as \"Jim and Pam\", "+
+					"but also the names of single characters holding them, such as \"Bob Vance, Vance Refrigeration\". An "+
+					"empty list leaves characters whole. Connections read from the API are only split into speakers when "+
+					"speaker_separators or speaker_groups is set.", separatorsDescription()),
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"speaker_groups": schema.MapAttribute{
+				Description: "Maps names of groups who say lines together to their members, in addition to the built-in " +
+					"groups such as \"Everyone\". Lines said by a group without members, like the built-in ones, are said by " +
+					"every other character speaking in the scene. Names are matched ignoring case and extra whitespace.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueListsAre(listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))),
+				},
+			},
+			"strict_decoding": schema.StringAttribute{
+				Description: fmt.Sprintf("Checks API responses against the API's schema, reporting unknown fields, missing "+
+					"required fields and values out of range, such as when a field was renamed. Violations are reported as "+
+					"warnings with \"%s\", and fail the read with \"%s\". Responses aren't checked when unset.",
+					strictDecodingWarn, strictDecodingError),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(strictDecodingWarn, strictDecodingError),
+				},
 			},
 		},
 	}
@@ -55,6 +159,15 @@ func (p *theOfficeProvider) Schema(ctx context.Context, req provider.SchemaReque
 func (p *theOfficeProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data theOfficeProviderModel
 
+	if !req.Config.Raw.IsFullyKnown() && req.ClientCapabilities.DeferralAllowed {
+		// Data sources are deferred along with the provider until its
+		// configuration is known.
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
@@ -62,6 +175,7 @@ func (p *theOfficeProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	// Configuration values are now available.
+
 	if data.Endpoint.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
@@ -71,6 +185,39 @@ func (p *theOfficeProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	var endpoints []types.String
+	if !data.Endpoints.IsUnknown() {
+		resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	}
+	if data.Endpoints.IsUnknown() || slices.ContainsFunc(endpoints, types.String.IsUnknown) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Unknown theOffice API endpoints",
+			"The provider cannot create theOffice API client as there is an unknown configuration value for theOffice API endpoints. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"default_characters", data.DefaultCharacters},
+		{"exclude_characters", data.ExcludeCharacters},
+		{"character_aliases", data.CharacterAliases},
+		{"speaker_separators", data.SpeakerSeparators},
+		{"speaker_groups", data.SpeakerGroups},
+	} {
+		if containsUnknown(setting.value) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				"Unknown theOffice Provider Setting",
+				fmt.Sprintf("The provider cannot be configured as there is an unknown configuration value for %s. ", setting.name)+
+					"Either target apply the source of the value first, or set the value statically in the configuration.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -83,19 +230,78 @@ func (p *theOfficeProvider) Configure(ctx context.Context, req provider.Configur
 
 	// Example client configuration for data sources and resources
 	client, err := theoffice.NewClient(&theoffice.Config{
-		Address: endpoint,
+		Address:        endpoint,
+		Addresses:      stringValues(endpoints),
+		Transport:      p.transport,
+		UserAgent:      userAgent(p.version),
+		StrictDecoding: !data.StrictDecoding.IsNull(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("error configuring theOffice client", err.Error())
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	var characterAliases map[string]string
+	resp.Diagnostics.Append(data.CharacterAliases.ElementsAs(ctx, &characterAliases, false)...)
+
+	var speakerGroups map[string][]string
+	resp.Diagnostics.Append(data.SpeakerGroups.ElementsAs(ctx, &speakerGroups, false)...)
+
+	defaultCharacters := listStrings(ctx, data.DefaultCharacters, &resp.Diagnostics)
+	excludeCharacters := listStrings(ctx, data.ExcludeCharacters, &resp.Diagnostics)
+	speakerSeparators := listStrings(ctx, data.SpeakerSeparators, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Names are left as they are unless aliases are configured.
+	var characterNames *theoffice.CharacterNames
+	if len(characterAliases) > 0 || data.BuiltinCharacterAliases.ValueBool() {
+		characterNames = theoffice.NewCharacterNames(characterAliases, data.BuiltinCharacterAliases.ValueBool())
+	}
+
+	providerData := &providerData{
+		client:  client,
+		limiter: newLimiter(defaultMaxConcurrentRequests),
+		settings: providerSettings{
+			defaultSeason:     data.DefaultSeason,
+			defaultCharacters: defaultCharacters,
+			excludeCharacters: excludeCharacters,
+			characterNames:    characterNames,
+			speakers: theoffice.NewSpeakerParser(theoffice.SpeakerOptions{
+				Separators: speakerSeparators,
+				Groups:     speakerGroups,
+				Names:      characterNames,
+			}),
+			splitConnections: !data.SpeakerSeparators.IsNull() || !data.SpeakerGroups.IsNull(),
+		},
+		version:        p.version,
+		transport:      p.transport,
+		strictDecoding: data.StrictDecoding.ValueString(),
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 
 	tflog.Info(ctx, "Configured theOffice client", map[string]any{"success": true})
 }
 
+// separatorsDescription returns the default speaker separators, quoted for
+// the schema's description.
+func separatorsDescription() string {
+	quoted := make([]string, 0, len(theoffice.DefaultSpeakerSeparators))
+	for _, sep := range theoffice.DefaultSpeakerSeparators {
+		quoted = append(quoted, fmt.Sprintf("%q", sep))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// userAgent returns the User-Agent header sent by the given provider version.
+func userAgent(version string) string {
+	return fmt.Sprintf("terraform-provider-theoffice/%s", version)
+}
+
 func (p *theOfficeProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return nil
 }
@@ -104,6 +310,14 @@ func (p *theOfficeProvider) DataSources(ctx context.Context) []func() datasource
 	return []func() datasource.DataSource{
 		NewQuotesDataSource,
 		NewConnectionsDataSource,
+		NewScenesDataSource,
+		NewTranscriptDataSource,
+		NewCharacterStatsDataSource,
+		NewPhraseStatsDataSource,
+		NewQuoteIndexSearchDataSource,
+		NewSimilarQuotesDataSource,
+		NewDatasetDiffDataSource,
+		NewAPIHealthDataSource,
 	}
 }
 
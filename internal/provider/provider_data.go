@@ -0,0 +1,431 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerData is passed by the provider's Configure to data sources and
+// resources, which read the API through it so that they share its cache and
+// limiter for the life of the provider. Provider-defined functions aren't
+// configured by the framework, so they can't share it.
+type providerData struct {
+	client   *theoffice.Client
+	cache    providerCache
+	limiter  limiter
+	settings providerSettings
+
+	// version is the provider version, as sent in the User-Agent header.
+	version string
+
+	// transport is used beneath the clients of other endpoints, as it is
+	// beneath client.
+	transport http.RoundTripper
+
+	// strictDecoding is how responses that don't match the API's schema are
+	// reported: strictDecodingWarn, strictDecodingError, or empty when they
+	// aren't checked.
+	strictDecoding string
+}
+
+const (
+	strictDecodingWarn  = "warn"
+	strictDecodingError = "error"
+)
+
+// providerCache holds API responses, and the search indexes built from them.
+type providerCache struct {
+	quotes      cache[episodeKey, checked[[]theoffice.Quote]]
+	connections cache[int, checked[[]theoffice.Connection]]
+	indexes     cache[episodeKey, *theoffice.QuoteIndex]
+}
+
+// checked is a response, and how it violates the API's schema when it is
+// kept despite that. The violations are warned about on every read of it.
+type checked[T any] struct {
+	value     T
+	schemaErr *theoffice.SchemaError
+}
+
+// check returns value, the response of a request that failed with err. With
+// strict_decoding set to warn, responses that don't match the API's schema
+// are kept.
+func check[T any](value T, err error, strictDecoding string) (checked[T], error) {
+	var schemaErr *theoffice.SchemaError
+	if errors.As(err, &schemaErr) && strictDecoding == strictDecodingWarn {
+		return checked[T]{value: value, schemaErr: schemaErr}, nil
+	}
+	if err != nil {
+		return checked[T]{}, err
+	}
+
+	return checked[T]{value: value}, nil
+}
+
+// warn reports the schema violations of the response, if any, as a warning.
+func (c checked[T]) warn(diags *diag.Diagnostics) T {
+	if c.schemaErr != nil {
+		diags.AddWarning(
+			"theOffice API Response Does Not Match Schema",
+			c.schemaErr.Error()+"\n\nThe response is used as decoded, which may leave fields empty. "+
+				"Set strict_decoding to \"error\" in the provider configuration to fail instead.",
+		)
+	}
+
+	return c.value
+}
+
+// episodeKey identifies an episode, every episode of a season when episode
+// is zero, or every season when season is zero too.
+type episodeKey struct {
+	season, episode int
+}
+
+// configureProviderData returns the provider data given to the Configure of
+// a data source or resource, or nil when the provider isn't configured yet.
+// kind names the caller in diagnostics, such as "Data Source".
+func configureProviderData(v any, kind string, diags *diag.Diagnostics) *providerData {
+	// Prevent panic if the provider has not been configured.
+	if v == nil {
+		return nil
+	}
+
+	data, ok := v.(*providerData)
+	if !ok {
+		diags.AddError(
+			fmt.Sprintf("Unexpected %s Configure Type", kind),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", v),
+		)
+	}
+
+	return data
+}
+
+// newClient returns a client of the API at address, configured like client
+// but without failover. Its responses aren't cached.
+func (p *providerData) newClient(address string) (*theoffice.Client, error) {
+	return theoffice.NewClient(&theoffice.Config{
+		Address:        address,
+		Transport:      p.transport,
+		UserAgent:      userAgent(p.version),
+		StrictDecoding: p.strictDecoding != "",
+	})
+}
+
+// newProbeClient returns a client of the same endpoints as client that sends
+// each request once, giving up after timeout, to check their health.
+func (p *providerData) newProbeClient(timeout time.Duration) (*theoffice.Client, error) {
+	return theoffice.NewClient(&theoffice.Config{
+		Addresses: p.client.Addresses(),
+		Doer:      &http.Client{Transport: p.transport, Timeout: timeout},
+		UserAgent: userAgent(p.version),
+	})
+}
+
+// limit calls f once the limiter has a free slot.
+func (p *providerData) limit(ctx context.Context, f func() error) error {
+	if err := p.limiter.acquire(ctx); err != nil {
+		return err
+	}
+	defer p.limiter.release()
+
+	return f()
+}
+
+// getQuotes returns the quotes of the given episode, or of every episode in
+// the season when episode is zero. The quotes are shared with other readers
+// and must not be modified. Schema violations are added to diags as warnings.
+func (p *providerData) getQuotes(ctx context.Context, season, episode int, diags *diag.Diagnostics) ([]theoffice.Quote, error) {
+	quotes, err := p.cache.quotes.get(episodeKey{season, episode}, func() (checked[[]theoffice.Quote], error) {
+		var resp *theoffice.QuotesResponse
+		err := p.limit(ctx, func() (err error) {
+			resp, err = p.client.GetQuotes(ctx, season, episode)
+			return err
+		})
+		if resp == nil {
+			return checked[[]theoffice.Quote]{}, err
+		}
+		return check(p.settings.normalizeQuotes(resp.Quotes), err, p.strictDecoding)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return quotes.warn(diags), nil
+}
+
+// getConnections returns the connections of every episode in the season. The
+// connections are shared with other readers and must not be modified. Schema
+// violations are added to diags as warnings.
+func (p *providerData) getConnections(ctx context.Context, season int, diags *diag.Diagnostics) ([]theoffice.Connection, error) {
+	connections, err := p.cache.connections.get(season, func() (checked[[]theoffice.Connection], error) {
+		var resp *theoffice.ConnectionsResponse
+		err := p.limit(ctx, func() (err error) {
+			resp, err = p.client.GetConnections(ctx, season)
+			return err
+		})
+		if resp == nil {
+			return checked[[]theoffice.Connection]{}, err
+		}
+		return check(p.settings.normalizeConnections(resp.Connections), err, p.strictDecoding)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connections.warn(diags), nil
+}
+
+// readQuotes returns the quotes of the given episode, of every episode in the
+// season when episode is null, or of every season when season is null too.
+// The quotes must not be modified.
+func (p *providerData) readQuotes(ctx context.Context, season, episode types.Int64, diags *diag.Diagnostics) ([]theoffice.Quote, error) {
+	if !season.IsNull() {
+		return p.getQuotes(ctx, int(season.ValueInt64()), int(episode.ValueInt64()), diags)
+	}
+
+	var quotes []theoffice.Quote
+	for s := 1; s <= theoffice.NumSeasons; s++ {
+		seasonQuotes, err := p.getQuotes(ctx, s, 0, diags)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, seasonQuotes...)
+	}
+
+	return quotes, nil
+}
+
+// quoteIndex returns a search index of the quotes readQuotes returns. Indexes
+// are built once and cached, while the quotes are read every time so that
+// their schema violations are warned about.
+func (p *providerData) quoteIndex(ctx context.Context, season, episode types.Int64, diags *diag.Diagnostics) (*theoffice.QuoteIndex, error) {
+	quotes, err := p.readQuotes(ctx, season, episode, diags)
+	if err != nil {
+		return nil, err
+	}
+
+	key := episodeKey{int(season.ValueInt64()), int(episode.ValueInt64())}
+	return p.cache.indexes.get(key, func() (*theoffice.QuoteIndex, error) {
+		return theoffice.NewQuoteIndex(quotes), nil
+	})
+}
+
+// providerSettings are the provider-level defaults data sources inherit
+// unless their own configuration overrides them.
+type providerSettings struct {
+	// defaultSeason is null when default_season is unset.
+	defaultSeason types.Int64
+
+	// defaultCharacters is nil when default_characters is unset.
+	defaultCharacters []string
+
+	excludeCharacters []string
+
+	// characterNames normalizes the names of characters in responses, and
+	// those data sources are configured with, through character_aliases and
+	// builtin_character_aliases. It is nil when neither is set.
+	characterNames *theoffice.CharacterNames
+
+	// speakers parses the characters of quotes and connections into their
+	// speakers, through speaker_separators and speaker_groups.
+	speakers *theoffice.SpeakerParser
+
+	// splitConnections is whether the links and nodes of connections read from
+	// the API are split into speakers, which is only when speaker_separators
+	// or speaker_groups is set. Otherwise they are kept as served.
+	splitConnections bool
+}
+
+// normalizeQuotes returns a copy of quotes with normalized character names
+// and parsed speakers.
+func (s providerSettings) normalizeQuotes(quotes []theoffice.Quote) []theoffice.Quote {
+	return s.speakers.Quotes(s.characterNames.Quotes(quotes))
+}
+
+// normalizeConnections returns a copy of connections with normalized
+// character names, where links and nodes of several speakers are split when
+// speaker parsing is configured.
+func (s providerSettings) normalizeConnections(connections []theoffice.Connection) []theoffice.Connection {
+	connections = s.characterNames.Connections(connections)
+	if !s.splitConnections {
+		return connections
+	}
+
+	return s.speakers.Connections(connections)
+}
+
+// resolveSeason returns season, or default_season when season is null. An
+// episode given without any season, or a missing season when required, is
+// reported at the season attribute. Episodes of a default season are checked
+// against the catalogue here, as they can't be at validate time.
+func (s providerSettings) resolveSeason(season, episode types.Int64, required bool, diags *diag.Diagnostics) types.Int64 {
+	if !season.IsNull() {
+		return season
+	}
+	season = s.defaultSeason
+
+	switch {
+	case season.IsNull() && (required || !episode.IsNull()):
+		diags.AddAttributeError(
+			path.Root("season"),
+			"Missing theOffice Season",
+			"A season is required. Set season, or default_season in the provider configuration.",
+		)
+	case !season.IsNull() && !episode.IsNull() && !theoffice.ValidEpisode(int(season.ValueInt64()), int(episode.ValueInt64())):
+		diags.AddAttributeError(
+			path.Root("episode"),
+			"Invalid theOffice Episode",
+			fmt.Sprintf("Season %d, the provider's default_season, has %d episodes, got episode %d.", season.ValueInt64(),
+				theoffice.NumEpisodes(int(season.ValueInt64())), episode.ValueInt64()),
+		)
+	}
+
+	return season
+}
+
+// characterScope returns the characters a data source reports on, from its
+// characters and exclude_characters arguments, or the provider's
+// default_characters and exclude_characters when they are null.
+func (s providerSettings) characterScope(characters, excludeCharacters []types.String) characterScope {
+	scope := characterScope{
+		include: s.defaultCharacters,
+		exclude: s.excludeCharacters,
+	}
+	if characters != nil {
+		scope.include = stringValues(characters)
+	}
+	if excludeCharacters != nil {
+		scope.exclude = stringValues(excludeCharacters)
+	}
+	scope.include = s.normalizeCharacters(scope.include)
+	scope.exclude = s.normalizeCharacters(scope.exclude)
+
+	return scope
+}
+
+// normalizeCharacters returns the normalized names of characters, keeping nil
+// for nil.
+func (s providerSettings) normalizeCharacters(characters []string) []string {
+	if characters == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(characters))
+	for _, c := range characters {
+		normalized = append(normalized, s.characterNames.Normalize(c))
+	}
+
+	return normalized
+}
+
+// charactersAttribute returns the schema of the characters argument, where
+// reported describes what the data source reports for each character.
+func charactersAttribute(reported string) schema.ListAttribute {
+	return schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: fmt.Sprintf("Characters to report %s for. Defaults to the provider's default_characters, "+
+			"or every character. An empty list selects every character.", reported),
+	}
+}
+
+// excludeCharactersAttribute returns the schema of the exclude_characters
+// argument, the counterpart of charactersAttribute.
+func excludeCharactersAttribute(reported string) schema.ListAttribute {
+	return schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: fmt.Sprintf("Characters not to report %s for. Defaults to the provider's exclude_characters.", reported),
+	}
+}
+
+// characterScope selects characters by name.
+type characterScope struct {
+	// include, when not empty, lists the only characters selected.
+	include []string
+	exclude []string
+}
+
+// allows reports whether the scope selects character.
+func (s characterScope) allows(character string) bool {
+	if len(s.include) > 0 && !slices.Contains(s.include, character) {
+		return false
+	}
+
+	return !s.excludes(character)
+}
+
+// allowsQuote reports whether the scope selects any of the quote's speakers.
+func (s characterScope) allowsQuote(q theoffice.Quote) bool {
+	return slices.ContainsFunc(q.SpeakerNames(), s.allows)
+}
+
+// excludes reports whether character is explicitly left out of the scope.
+func (s characterScope) excludes(character string) bool {
+	return slices.Contains(s.exclude, character)
+}
+
+// listStrings returns the values of a list of strings, keeping nil for a null
+// list and an empty slice for an empty one.
+func listStrings(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() {
+		return nil
+	}
+
+	values := []string{}
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+
+	return values
+}
+
+// containsUnknown reports whether value, or any value nested in it, is
+// unknown.
+func containsUnknown(value attr.Value) bool {
+	if value.IsUnknown() {
+		return true
+	}
+
+	switch v := value.(type) {
+	case types.List:
+		return slices.ContainsFunc(v.Elements(), containsUnknown)
+	case types.Map:
+		for _, e := range v.Elements() {
+			if containsUnknown(e) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// stringValues returns the values of a list of strings, keeping nil for a
+// null list.
+func stringValues(list []types.String) []string {
+	if list == nil {
+		return nil
+	}
+
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		values = append(values, v.ValueString())
+	}
+
+	return values
+}
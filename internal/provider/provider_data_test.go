@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderSettings_resolveSeason(t *testing.T) {
+	unset := providerSettings{defaultSeason: types.Int64Null()}
+	withDefault := providerSettings{defaultSeason: types.Int64Value(1)}
+
+	for name, tc := range map[string]struct {
+		settings providerSettings
+		season   types.Int64
+		episode  types.Int64
+		required bool
+		want     types.Int64
+		wantErr  string
+	}{
+		"configured": {
+			settings: withDefault,
+			season:   types.Int64Value(2),
+			episode:  types.Int64Null(),
+			want:     types.Int64Value(2),
+		},
+		"default": {
+			settings: withDefault,
+			season:   types.Int64Null(),
+			episode:  types.Int64Value(6),
+			required: true,
+			want:     types.Int64Value(1),
+		},
+		"every season": {
+			settings: unset,
+			season:   types.Int64Null(),
+			episode:  types.Int64Null(),
+			want:     types.Int64Null(),
+		},
+		"missing": {
+			settings: unset,
+			season:   types.Int64Null(),
+			episode:  types.Int64Null(),
+			required: true,
+			wantErr:  "Missing theOffice Season",
+		},
+		"episode without season": {
+			settings: unset,
+			season:   types.Int64Null(),
+			episode:  types.Int64Value(1),
+			wantErr:  "Missing theOffice Season",
+		},
+		"episode not in default season": {
+			settings: withDefault,
+			season:   types.Int64Null(),
+			episode:  types.Int64Value(7),
+			wantErr:  "Invalid theOffice Episode",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := tc.settings.resolveSeason(tc.season, tc.episode, tc.required, &diags)
+
+			if tc.wantErr != "" {
+				assert.True(t, diags.HasError())
+				assert.Equal(t, tc.wantErr, diags.Errors()[0].Summary())
+				return
+			}
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestProviderSettings_characterScope(t *testing.T) {
+	settings := providerSettings{
+		defaultCharacters: []string{"Michael", "Dwight"},
+		excludeCharacters: []string{"Dwight"},
+	}
+
+	inherited := settings.characterScope(nil, nil)
+	assert.True(t, inherited.allows("Michael"))
+	assert.False(t, inherited.allows("Dwight"))
+	assert.False(t, inherited.allows("Jim"))
+
+	overridden := settings.characterScope([]types.String{}, []types.String{types.StringValue("Michael")})
+	assert.False(t, overridden.allows("Michael"))
+	assert.True(t, overridden.allows("Dwight"))
+	assert.True(t, overridden.allows("Jim"))
+	assert.True(t, overridden.excludes("Michael"))
+}
+
+func TestProviderSettings_characterScope_aliases(t *testing.T) {
+	settings := providerSettings{
+		excludeCharacters: []string{"Toby Flenderson"},
+		characterNames:    theoffice.NewCharacterNames(map[string]string{"Mike": "Michael"}, true),
+	}
+
+	scope := settings.characterScope([]types.String{types.StringValue("Mike"), types.StringValue("toby")}, nil)
+	assert.True(t, scope.allows("Michael"))
+	assert.False(t, scope.allows("Toby"))
+	assert.True(t, scope.excludes("Toby"))
+}
+
+func TestProviderDataQuoteIndex(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+
+		_, err := w.Write([]byte(`[{"season": 1,"episode": 2,"scene": 1,"episode_name": "Diversity Day","character": "Dwight","quote": "Question."}]`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	client, err := theoffice.NewClient(&theoffice.Config{
+		Address: srv.URL,
+	})
+	assert.NoError(t, err)
+	data := &providerData{client: client, limiter: newLimiter(defaultMaxConcurrentRequests)}
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	idx, err := data.quoteIndex(ctx, types.Int64Value(1), types.Int64Value(2), &diags)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, idx.Len())
+
+	cached, err := data.quoteIndex(ctx, types.Int64Value(1), types.Int64Value(2), &diags)
+	assert.NoError(t, err)
+	assert.Same(t, idx, cached)
+
+	quotes, err := data.getQuotes(ctx, 1, 2, &diags)
+	assert.NoError(t, err)
+	assert.Equal(t, "Question.", quotes[0].Quote)
+	assert.Equal(t, []string{"/season/1/episode/2"}, requests)
+
+	all, err := data.quoteIndex(ctx, types.Int64Null(), types.Int64Null(), &diags)
+	assert.NoError(t, err)
+	assert.Equal(t, theoffice.NumSeasons, all.Len())
+	assert.Equal(t, 1+theoffice.NumSeasons, len(requests))
+	assert.Empty(t, diags)
+}
+
+func TestProviderDataStrictDecoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`[{"season": 1,"episode": 2,"scene": 1,"episode_name": "Diversity Day","speaker": "Dwight","quote": "Question."}]`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	for _, strictDecoding := range []string{strictDecodingWarn, strictDecodingError} {
+		t.Run(strictDecoding, func(t *testing.T) {
+			client, err := theoffice.NewClient(&theoffice.Config{
+				Address:        srv.URL,
+				StrictDecoding: true,
+			})
+			assert.NoError(t, err)
+			data := &providerData{client: client, limiter: newLimiter(defaultMaxConcurrentRequests), strictDecoding: strictDecoding}
+
+			// Reads of cached responses are warned about too.
+			for range 2 {
+				var diags diag.Diagnostics
+				quotes, err := data.getQuotes(context.Background(), 1, 2, &diags)
+
+				if strictDecoding == strictDecodingError {
+					var schemaErr *theoffice.SchemaError
+					assert.ErrorAs(t, err, &schemaErr)
+					assert.Empty(t, diags)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, "Question.", quotes[0].Quote)
+				assert.Equal(t, 1, diags.WarningsCount())
+				assert.Contains(t, diags.Warnings()[0].Detail(), `unknown field "speaker"`)
+			}
+		})
+	}
+}
+
+func TestCache_errorsAreNotCached(t *testing.T) {
+	var c cache[int, string]
+	calls := 0
+	fetch := func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("unavailable")
+		}
+		return "ok", nil
+	}
+
+	_, err := c.get(1, fetch)
+	assert.Error(t, err)
+
+	value, err := c.get(1, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", value)
+
+	value, err = c.get(1, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", value)
+	assert.Equal(t, 2, calls)
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(1)
+	assert.NoError(t, l.acquire(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.acquire(ctx), context.Canceled)
+
+	l.release()
+	assert.NoError(t, l.acquire(context.Background()))
+}
@@ -4,8 +4,20 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,3 +27,224 @@ import (
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"theoffice": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+// testAccProtoV6ProviderFactoriesWithCassette returns provider factories whose
+// clients record or replay interactions in testdata/fixture-cassettes/<test>.json,
+// as selected by THEOFFICE_VCR_MODE. Cassettes are recorded against the
+// theofficetest fixtures, not the live API, which is only used when the
+// variable is unset.
+func testAccProtoV6ProviderFactoriesWithCassette(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+
+	mode := os.Getenv(theoffice.RecorderModeEnvVar)
+
+	var next http.RoundTripper
+	if mode == theoffice.RecorderModeRecord {
+		next = fixtureTransport{handler: theofficetest.NewUnstartedServer().Handler()}
+	}
+
+	recorder, err := theoffice.NewRecorder(mode, filepath.Join("testdata", "fixture-cassettes", t.Name()+".json"), next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Errorf("saving cassette: %s", err)
+		}
+	})
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"theoffice": providerserver.NewProtocol6WithError(&theOfficeProvider{
+			version:   "test",
+			transport: recorder,
+		}),
+	}
+}
+
+// fixtureTransport serves requests from the theofficetest fixtures, whichever
+// endpoint they are sent to.
+type fixtureTransport struct {
+	handler http.Handler
+}
+
+func (t fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, req)
+
+	res := w.Result()
+	res.Request = req
+
+	return res, nil
+}
+
+func TestAccProvider_endpointsFailover(t *testing.T) {
+	down := theofficetest.NewServer()
+	down.Close()
+	mirror := theofficetest.NewServer()
+	defer mirror.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig_endpoints(down.URL, mirror.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "9"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.episode_name", "The Dundies"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_endpointsConflict(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "theoffice" {
+  endpoint  = "https://the-office.fly.dev"
+  endpoints = ["https://the-office.fly.dev"]
+}
+
+data "theoffice_quotes" "test" {
+  season = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_strictDecoding(t *testing.T) {
+	// The API renamed the character field, so it decodes empty.
+	dataset := theofficetest.DefaultDataset()
+	for i := range dataset.Quotes {
+		dataset.Quotes[i].Character = ""
+	}
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig_strictDecoding(srv.URL, "warn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "9"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", ""),
+				),
+			},
+			{
+				Config:      testAccProviderConfig_strictDecoding(srv.URL, "error"),
+				ExpectError: regexp.MustCompile(`quotes\[0\]: missing character`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_characterAliases(t *testing.T) {
+	dataset := theofficetest.DefaultDataset()
+	for i, q := range dataset.Quotes {
+		if q.Season == 2 && q.Character == "Michael" {
+			dataset.Quotes[i].Character = "MICHAEL SCOTT"
+			break
+		}
+	}
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig_characterAliases(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "Michael Scott"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.character", "Michael Scott"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.2.character", "Michael Scott"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_characterAliasesUnset(t *testing.T) {
+	dataset := theofficetest.DefaultDataset()
+	for i, q := range dataset.Quotes {
+		if q.Season == 2 && q.Character == "Michael" {
+			dataset.Quotes[i].Character = "MICHAEL SCOTT"
+			break
+		}
+	}
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Names are left as the API returns them without aliases.
+			{
+				Config: testAccQuotesDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "MICHAEL SCOTT"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.2.character", "Michael"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProviderConfig_endpoints(primary, mirror string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoints = [%[1]q, %[2]q]
+}
+
+data "theoffice_quotes" "test" {
+  season  = 2
+  episode = 1
+}
+`, primary, mirror)
+}
+
+func testAccProviderConfig_strictDecoding(endpoint, strictDecoding string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint        = %[1]q
+  strict_decoding = %[2]q
+}
+
+data "theoffice_quotes" "test" {
+  season  = 2
+  episode = 1
+}
+`, endpoint, strictDecoding)
+}
+
+func testAccProviderConfig_characterAliases(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+
+  builtin_character_aliases = true
+
+  character_aliases = {
+    "Michael" = "Michael Scott"
+  }
+}
+
+data "theoffice_quotes" "test" {
+  season     = 2
+  episode    = 1
+  characters = ["michael"]
+}
+`, endpoint)
+}
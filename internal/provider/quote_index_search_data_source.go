@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &QuoteIndexSearchDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &QuoteIndexSearchDataSource{}
+)
+
+func NewQuoteIndexSearchDataSource() datasource.DataSource {
+	return &QuoteIndexSearchDataSource{}
+}
+
+// QuoteIndexSearchDataSource defines the data source implementation.
+type QuoteIndexSearchDataSource struct {
+	providerData *providerData
+}
+
+// QuoteIndexSearchDataSourceModel describes the data source data model.
+type QuoteIndexSearchDataSourceModel struct {
+	Query             types.String         `tfsdk:"query"`
+	Season            types.Int64          `tfsdk:"season"`
+	Episode           types.Int64          `tfsdk:"episode"`
+	Characters        []types.String       `tfsdk:"characters"`
+	ExcludeCharacters []types.String       `tfsdk:"exclude_characters"`
+	Fuzziness         types.Int64          `tfsdk:"fuzziness"`
+	Limit             types.Int64          `tfsdk:"limit"`
+	MinScore          types.Float64        `tfsdk:"min_score"`
+	Results           []searchResultsModel `tfsdk:"results"`
+	ID                types.String         `tfsdk:"id"`
+}
+
+type searchResultsModel struct {
+	Season      types.Int64   `tfsdk:"season"`
+	Episode     types.Int64   `tfsdk:"episode"`
+	Scene       types.Int64   `tfsdk:"scene"`
+	EpisodeName types.String  `tfsdk:"episode_name"`
+	Character   types.String  `tfsdk:"character"`
+	Quote       types.String  `tfsdk:"quote"`
+	Score       types.Float64 `tfsdk:"score"`
+}
+
+func (d *QuoteIndexSearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quote_index_search"
+}
+
+func (d *QuoteIndexSearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Searches quotes with a full-text index ranked by BM25. " +
+			"A query is made of whitespace separated clauses, and quotes matching any clause are returned: " +
+			"a plain word matches the word exactly, or within `fuzziness` edits; " +
+			"a word ending in `*` matches words with that prefix; " +
+			"a word ending in `~` matches words within one edit, or within N edits with `~N`; " +
+			"and words in double quotes match the exact phrase.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"query": schema.StringAttribute{
+				Required:    true,
+				Description: "The search query",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"season": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number to search. Defaults to the provider's default_season, or every season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"episode": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Episode number to search. Requires season, or the provider's default_season.",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
+			},
+			"fuzziness": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The number of edits, up to %d, within which plain query words match (default: 0)", theoffice.MaxFuzziness),
+				Validators: []validator.Int64{
+					int64validator.Between(0, theoffice.MaxFuzziness),
+				},
+			},
+			"characters":         charactersAttribute("matching quotes"),
+			"exclude_characters": excludeCharactersAttribute("matching quotes"),
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of results (default: %d)", theoffice.DefaultSearchLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_score": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The minimum score of the results",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "List of matching quotes, ordered by descending score",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"season": schema.Int64Attribute{
+							Description: "The season the quote occurred in.",
+							Computed:    true,
+						},
+						"episode": schema.Int64Attribute{
+							Description: "The episode the quote occurred in.",
+							Computed:    true,
+						},
+						"scene": schema.Int64Attribute{
+							Description: "The scene the quote occurred in.",
+							Computed:    true,
+						},
+						"episode_name": schema.StringAttribute{
+							Description: "The name of the episode the quote occurred in.",
+							Computed:    true,
+						},
+						"character": schema.StringAttribute{
+							Description: "The character who said the quote.",
+							Computed:    true,
+						},
+						"quote": schema.StringAttribute{
+							Description: "The quote as a string",
+							Computed:    true,
+						},
+						"score": schema.Float64Attribute{
+							Description: "The BM25 relevance of the quote to the query.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *QuoteIndexSearchDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
+	}
+}
+
+func (d *QuoteIndexSearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *QuoteIndexSearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QuoteIndexSearchDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope := d.providerData.settings.characterScope(data.Characters, data.ExcludeCharacters)
+
+	// Read Terraform configuration data into the model
+	idx, err := d.providerData.quoteIndex(ctx, data.Season, data.Episode, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read theOffice Quotes",
+			err.Error(),
+		)
+		return
+	}
+
+	results := idx.Search(data.Query.ValueString(), theoffice.SearchOptions{
+		Limit:     int(data.Limit.ValueInt64()),
+		MinScore:  data.MinScore.ValueFloat64(),
+		Fuzziness: int(data.Fuzziness.ValueInt64()),
+		Exclude: func(q theoffice.Quote) bool {
+			return !scope.allowsQuote(q)
+		},
+	})
+
+	data.Results = []searchResultsModel{}
+	for _, r := range results {
+		data.Results = append(data.Results, searchResultsModel{
+			Season:      types.Int64Value(int64(r.Quote.Season)),
+			Episode:     types.Int64Value(int64(r.Quote.Episode)),
+			Scene:       types.Int64Value(int64(r.Quote.Scene)),
+			EpisodeName: types.StringValue(r.Quote.EpisodeName),
+			Character:   types.StringValue(r.Quote.Character),
+			Quote:       types.StringValue(r.Quote.Quote),
+			Score:       types.Float64Value(r.Score),
+		})
+	}
+
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read quote index search data source")
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccQuoteIndexSearchDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuoteIndexSearchDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.theoffice_quote_index_search.test", "results.#"),
+					resource.TestCheckResourceAttrSet("data.theoffice_quote_index_search.test", "results.0.score"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuoteIndexSearchDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuoteIndexSearchDataSourceConfig_stubServer(srv.URL, `"\"that's what she said\" whassup~"`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.#", "5"),
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.0.quote", "That's what she said."),
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.0.score", "10.7358"),
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.2.quote", "Whassup!"),
+				),
+			},
+			{
+				Config: testAccQuoteIndexSearchDataSourceConfig_stubServer(srv.URL, `"\"that's what she said\" whassup~"`, "min_score = 5"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuoteIndexSearchDataSource_fuzziness(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuoteIndexSearchDataSourceConfig_stubServer(srv.URL, `"quarterleis"`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.#", "0"),
+				),
+			},
+			{
+				Config: testAccQuoteIndexSearchDataSourceConfig_stubServer(srv.URL, `"quarterleis"`, "fuzziness = 2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.0.character", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_quote_index_search.test", "results.0.scene", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccQuoteIndexSearchDataSourceConfig = `
+data "theoffice_quote_index_search" "test" {
+  season = 1
+  query  = "\"that's what she said\""
+  limit  = 5
+}
+`
+
+func testAccQuoteIndexSearchDataSourceConfig_stubServer(endpoint, query, extra string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_quote_index_search" "test" {
+  season = 1
+  query  = %[2]s
+  %[3]s
+}
+`, endpoint, query, extra)
+}
@@ -5,12 +5,17 @@ package provider
 
 import (
 	"context"
-	"fmt"
+	"slices"
 
 	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,7 +23,8 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ datasource.DataSource = &QuotesDataSource{}
+	_ datasource.DataSource                     = &QuotesDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &QuotesDataSource{}
 )
 
 func NewQuotesDataSource() datasource.DataSource {
@@ -27,24 +33,36 @@ func NewQuotesDataSource() datasource.DataSource {
 
 // QuotesDataSource defines the data source implementation.
 type QuotesDataSource struct {
-	client *theoffice.Client
+	providerData *providerData
 }
 
 // QuotesDataSourceModel describes the data source data model.
 type QuotesDataSourceModel struct {
-	Episode types.Int64   `tfsdk:"episode"`
-	Season  types.Int64   `tfsdk:"season"`
-	Quotes  []quotesModel `tfsdk:"quotes"`
-	ID      types.String  `tfsdk:"id"`
+	Episode           types.Int64            `tfsdk:"episode"`
+	Season            types.Int64            `tfsdk:"season"`
+	Characters        []types.String         `tfsdk:"characters"`
+	ExcludeCharacters []types.String         `tfsdk:"exclude_characters"`
+	IncludeSentiment  types.Bool             `tfsdk:"include_sentiment"`
+	MinSentiment      types.Float64          `tfsdk:"min_sentiment"`
+	MaxSentiment      types.Float64          `tfsdk:"max_sentiment"`
+	Limit             types.Int64            `tfsdk:"limit"`
+	Offset            types.Int64            `tfsdk:"offset"`
+	OrderBy           types.String           `tfsdk:"order_by"`
+	TotalCount        types.Int64            `tfsdk:"total_count"`
+	Quotes            []quotesModel          `tfsdk:"quotes"`
+	QuotesByKey       map[string]quotesModel `tfsdk:"quotes_by_key"`
+	ID                types.String           `tfsdk:"id"`
 }
 
 type quotesModel struct {
-	Season      types.Int64  `tfsdk:"season"`
-	Episode     types.Int64  `tfsdk:"episode"`
-	Scene       types.Int64  `tfsdk:"scene"`
-	EpisodeName types.String `tfsdk:"episode_name"`
-	Character   types.String `tfsdk:"character"`
-	Quote       types.String `tfsdk:"quote"`
+	Season      types.Int64    `tfsdk:"season"`
+	Episode     types.Int64    `tfsdk:"episode"`
+	Scene       types.Int64    `tfsdk:"scene"`
+	EpisodeName types.String   `tfsdk:"episode_name"`
+	Character   types.String   `tfsdk:"character"`
+	Speakers    []types.String `tfsdk:"speakers"`
+	Quote       types.String   `tfsdk:"quote"`
+	Sentiment   types.Float64  `tfsdk:"sentiment"`
 }
 
 func (d *QuotesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,75 +81,154 @@ func (d *QuotesDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			},
 			"episode": schema.Int64Attribute{
 				Optional:    true,
-				Description: "Episode number to filter results by",
+				Description: "Episode number to filter results by. Requires season, or the provider's default_season.",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
 			},
 			"season": schema.Int64Attribute{
-				Required:    true,
-				Description: "Season number to filter results by",
-			},
-			"quotes": schema.ListNestedAttribute{
-				Description: "List of quotes",
+				Optional:    true,
 				Computed:    true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"season": schema.Int64Attribute{
-							Description: "The season the quote occurred in.",
-							Computed:    true,
-						},
-						"episode": schema.Int64Attribute{
-							Description: "The episode the quote occurred in.",
-							Computed:    true,
-						},
-						"scene": schema.Int64Attribute{
-							Description: "The scene the quote occurred in.",
-							Computed:    true,
-						},
-						"episode_name": schema.StringAttribute{
-							Description: "The name of the episode the quote occurred in.",
-							Computed:    true,
-						},
-						"character": schema.StringAttribute{
-							Description: "The character who said the quote.",
-							Computed:    true,
-						},
-						"quote": schema.StringAttribute{
-							Description: "The quote as a string",
-							Computed:    true,
-						},
-					},
+				Description: "Season number to filter results by. Required unless the provider sets default_season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"characters":         charactersAttribute("quotes"),
+			"exclude_characters": excludeCharactersAttribute("quotes"),
+			"include_sentiment": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to score the sentiment of each quote (default: false)",
+			},
+			"min_sentiment": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Minimum sentiment score, from -1 to 1, of the quotes to return",
+				Validators: []validator.Float64{
+					float64validator.Between(-1, 1),
+				},
+			},
+			"max_sentiment": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum sentiment score, from -1 to 1, of the quotes to return",
+				Validators: []validator.Float64{
+					float64validator.Between(-1, 1),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of quotes to return. Every quote is returned when unset.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"offset": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of quotes to skip before returning any (default: 0)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"order_by": schema.StringAttribute{
+				Optional: true,
+				Description: "The order to return quotes in: \"scene\" (default) as they occur, \"character\" alphabetically " +
+					"by character, or \"length\" from the longest quote to the shortest",
+				Validators: []validator.String{
+					stringvalidator.OneOf(theoffice.QuoteOrders...),
 				},
 			},
+			"total_count": schema.Int64Attribute{
+				Description: "The number of quotes matching the filters, before limit and offset are applied.",
+				Computed:    true,
+			},
+			"quotes": schema.ListNestedAttribute{
+				Description:  "List of quotes",
+				Computed:     true,
+				NestedObject: quoteNestedObject(),
+			},
+			"quotes_by_key": schema.MapNestedAttribute{
+				Description: "The quotes of the quotes attribute, keyed by their position in the show, such as \"s1e1sc3-2\" " +
+					"for the second line of scene 3 of season 1, episode 1. Keys don't change when other quotes are added or " +
+					"filtered out, so they are suitable for for_each.",
+				Computed:     true,
+				NestedObject: quoteNestedObject(),
+			},
 		},
 	}
 }
 
-func (d *QuotesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
-		return
+// quoteNestedObject returns the schema of a quote.
+func quoteNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"season": schema.Int64Attribute{
+				Description: "The season the quote occurred in.",
+				Computed:    true,
+			},
+			"episode": schema.Int64Attribute{
+				Description: "The episode the quote occurred in.",
+				Computed:    true,
+			},
+			"scene": schema.Int64Attribute{
+				Description: "The scene the quote occurred in.",
+				Computed:    true,
+			},
+			"episode_name": schema.StringAttribute{
+				Description: "The name of the episode the quote occurred in.",
+				Computed:    true,
+			},
+			"character": schema.StringAttribute{
+				Description: "The character who said the quote, as the API names it, unless renamed by the provider's " +
+					"character_aliases or builtin_character_aliases.",
+				Computed: true,
+			},
+			"speakers": schema.ListAttribute{
+				Description: "The characters who said the quote, parsed from character: lines said together, such as by " +
+					"\"Jim & Pam\", list each speaker, and groups such as \"Everyone\" list the other characters speaking in the scene.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"quote": schema.StringAttribute{
+				Description: "The quote as a string",
+				Computed:    true,
+			},
+			"sentiment": schema.Float64Attribute{
+				Description: "The sentiment of the quote, from -1 (most negative) to 1 (most positive). Only set when include_sentiment is true.",
+				Computed:    true,
+			},
+		},
 	}
+}
 
-	client, ok := req.ProviderData.(*theoffice.Client)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *theoffice.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
-		return
+func (d *QuotesDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
 	}
+}
 
-	d.client = client
+func (d *QuotesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
 }
 
 func (d *QuotesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data QuotesDataSourceModel
 
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope := d.providerData.settings.characterScope(data.Characters, data.ExcludeCharacters)
 
 	// Read Terraform configuration data into the model
-	quotes, err := d.client.GetQuotes(ctx, int(data.Season.ValueInt64()), int(data.Episode.ValueInt64()))
+	quotes, err := d.providerData.getQuotes(ctx, int(data.Season.ValueInt64()), int(data.Episode.ValueInt64()), &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read theOffice Quotes",
@@ -140,17 +237,64 @@ func (d *QuotesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	for _, quote := range quotes.Quotes {
+	// Quotes are only scored when their sentiment is filtered on or
+	// returned, and each is scored once, keyed by its position in the show.
+	scoreSentiment := data.IncludeSentiment.ValueBool() || !data.MinSentiment.IsNull() || !data.MaxSentiment.IsNull()
+	sentiments := map[string]float64{}
+
+	var matched []theoffice.KeyedQuote
+	for _, quote := range theoffice.KeyQuotes(quotes) {
+		if !scope.allowsQuote(quote.Quote) {
+			continue
+		}
+		if scoreSentiment {
+			sentiment := theoffice.Sentiment(quote.Quote.Quote)
+			if !data.MinSentiment.IsNull() && sentiment < data.MinSentiment.ValueFloat64() {
+				continue
+			}
+			if !data.MaxSentiment.IsNull() && sentiment > data.MaxSentiment.ValueFloat64() {
+				continue
+			}
+			sentiments[quote.Key] = sentiment
+		}
+		matched = append(matched, quote)
+	}
+
+	compare, err := theoffice.QuoteOrderFunc(data.OrderBy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("order_by"),
+			"Unable to Order theOffice Quotes",
+			err.Error(),
+		)
+		return
+	}
+	slices.SortStableFunc(matched, func(a, b theoffice.KeyedQuote) int {
+		return compare(a.Quote, b.Quote)
+	})
+	data.TotalCount = types.Int64Value(int64(len(matched)))
+
+	data.QuotesByKey = map[string]quotesModel{}
+	for _, quote := range theoffice.Page(matched, int(data.Offset.ValueInt64()), int(data.Limit.ValueInt64())) {
 		quoteState := quotesModel{
 			Season:      types.Int64Value(int64(quote.Season)),
 			Episode:     types.Int64Value(int64(quote.Episode)),
 			Scene:       types.Int64Value(int64(quote.Scene)),
 			EpisodeName: types.StringValue(quote.EpisodeName),
 			Character:   types.StringValue(quote.Character),
-			Quote:       types.StringValue(quote.Quote),
+			Speakers:    []types.String{},
+			Quote:       types.StringValue(quote.Quote.Quote),
+			Sentiment:   types.Float64Null(),
+		}
+		for _, speaker := range quote.SpeakerNames() {
+			quoteState.Speakers = append(quoteState.Speakers, types.StringValue(speaker))
+		}
+		if data.IncludeSentiment.ValueBool() {
+			quoteState.Sentiment = types.Float64Value(sentiments[quote.Key])
 		}
 
 		data.Quotes = append(data.Quotes, quoteState)
+		data.QuotesByKey[quote.Key] = quoteState
 	}
 
 	data.ID = types.StringValue("placeholder")
@@ -4,14 +4,18 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccQuotesDataSource(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
 		Steps: []resource.TestStep{
 			// Read testing
 			{
@@ -26,7 +30,7 @@ func TestAccQuotesDataSource(t *testing.T) {
 
 func TestAccQuotesDataSource_filterByEpisode(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
 		Steps: []resource.TestStep{
 			// Read testing
 			{
@@ -40,6 +44,258 @@ func TestAccQuotesDataSource_filterByEpisode(t *testing.T) {
 	})
 }
 
+func TestAccQuotesDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "9"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.episode_name", "The Dundies"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "Michael"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_sentiment(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_sentiment(srv.URL, `min_sentiment = 0.5`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "5"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.sentiment", "0.6325"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.character", "Dwight"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.sentiment", "0.898"),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_sentiment(srv.URL, `max_sentiment = -0.5`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.quote", "I hate parties."),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.sentiment", "-0.7746"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_sentimentNotIncluded(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "9"),
+					resource.TestCheckNoResourceAttr("data.theoffice_quotes.test", "quotes.0.sentiment"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_paging(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_paging(srv.URL, 3, 2, "character"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "9"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "Dwight"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.character", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.2.character", "Michael"),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_paging(srv.URL, 1, 0, "length"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "9"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.quote", "The Dundies are like our Oscars. Everybody gets an award."),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_paging(srv.URL, 5, 9, "scene"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "9"),
+					resource.TestCheckNoResourceAttr("data.theoffice_quotes.test", "quotes.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_byKey(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes_by_key.%", "9"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes_by_key.s2e1sc1-1.character", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes_by_key.s2e1sc3-3.quote", "I hate parties."),
+				),
+			},
+			{
+				// Keys don't shift when other quotes are filtered out.
+				Config: testAccQuotesDataSourceConfig_sentiment(srv.URL, `max_sentiment = -0.5`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes_by_key.%", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes_by_key.s2e1sc3-3.quote", "I hate parties."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_invalidEpisode(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccQuotesDataSourceConfig_episode(42, 1),
+				ExpectError: regexp.MustCompile(`season value must be between 1 and 9, got: 42`),
+			},
+			{
+				Config:      testAccQuotesDataSourceConfig_episode(1, -1),
+				ExpectError: regexp.MustCompile(`episode value must be between 1 and 26, got: -1`),
+			},
+			{
+				Config:      testAccQuotesDataSourceConfig_episode(1, 7),
+				ExpectError: regexp.MustCompile(`Season 1 has 6 episodes, got episode 7`),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_providerDefaults(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_providerDefaults(srv.URL, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "season", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "6"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.season", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "Dwight"),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_providerDefaults(srv.URL, `
+  characters         = ["Michael"]
+  exclude_characters = []`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "Michael"),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_providerDefaults(srv.URL, "season = 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "season", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.episode_name", "Pilot"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_speakers(t *testing.T) {
+	dataset := theofficetest.DefaultDataset()
+	for i, q := range dataset.Quotes {
+		switch {
+		case q.Season == 2 && q.Character == "Jim":
+			dataset.Quotes[i].Character = "Jim & Pam"
+		case q.Season == 2 && q.Character == "Oscar":
+			dataset.Quotes[i].Character = "Everyone"
+		}
+	}
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccQuotesDataSourceConfig_speakers(srv.URL, "", "Jim"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.character", "Jim & Pam"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.speakers.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.speakers.0", "Jim"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.0.speakers.1", "Pam"),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_speakers(srv.URL, "", "Angela"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.character", "Everyone"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.speakers.#", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "quotes.1.speakers.0", "Angela"),
+				),
+			},
+			{
+				Config: testAccQuotesDataSourceConfig_speakers(srv.URL, "speaker_separators = []", "Jim"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_quotes.test", "total_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQuotesDataSource_missingSeason(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "theoffice_quotes" "test" {
+  episode = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`A season is required`),
+			},
+		},
+	})
+}
+
 const testAccQuotesDataSourceConfig = `
 data "theoffice_quotes" "test" {
   season = 1
@@ -52,3 +308,88 @@ data "theoffice_quotes" "test" {
   episode = 1
 }
 `
+
+func testAccQuotesDataSourceConfig_stubServer(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_quotes" "test" {
+  season = 2
+  episode = 1
+}
+`, endpoint)
+}
+
+func testAccQuotesDataSourceConfig_sentiment(endpoint, filter string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_quotes" "test" {
+  season  = 2
+  episode = 1
+
+  include_sentiment = true
+  %[2]s
+}
+`, endpoint, filter)
+}
+
+func testAccQuotesDataSourceConfig_paging(endpoint string, limit, offset int, orderBy string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_quotes" "test" {
+  season  = 2
+  episode = 1
+
+  limit    = %[2]d
+  offset   = %[3]d
+  order_by = %[4]q
+}
+`, endpoint, limit, offset, orderBy)
+}
+
+func testAccQuotesDataSourceConfig_episode(season, episode int) string {
+	return fmt.Sprintf(`
+data "theoffice_quotes" "test" {
+  season  = %[1]d
+  episode = %[2]d
+}
+`, season, episode)
+}
+
+func testAccQuotesDataSourceConfig_providerDefaults(endpoint, extra string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint           = %[1]q
+  default_season     = 2
+  exclude_characters = ["Michael"]
+}
+
+data "theoffice_quotes" "test" {
+  episode = 1
+  %[2]s
+}
+`, endpoint, extra)
+}
+
+func testAccQuotesDataSourceConfig_speakers(endpoint, extra, character string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+  %[2]s
+}
+
+data "theoffice_quotes" "test" {
+  season     = 2
+  episode    = 1
+  characters = [%[3]q]
+}
+`, endpoint, extra, character)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &ScenesDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ScenesDataSource{}
+)
+
+func NewScenesDataSource() datasource.DataSource {
+	return &ScenesDataSource{}
+}
+
+// ScenesDataSource defines the data source implementation.
+type ScenesDataSource struct {
+	providerData *providerData
+}
+
+// ScenesDataSourceModel describes the data source data model.
+type ScenesDataSourceModel struct {
+	Season      types.Int64   `tfsdk:"season"`
+	Episode     types.Int64   `tfsdk:"episode"`
+	EpisodeName types.String  `tfsdk:"episode_name"`
+	Scenes      []scenesModel `tfsdk:"scenes"`
+	ID          types.String  `tfsdk:"id"`
+}
+
+type scenesModel struct {
+	Scene      types.Int64       `tfsdk:"scene"`
+	Lines      []sceneLinesModel `tfsdk:"lines"`
+	Characters []types.String    `tfsdk:"characters"`
+	LineCount  types.Int64       `tfsdk:"line_count"`
+	WordCount  types.Int64       `tfsdk:"word_count"`
+}
+
+type sceneLinesModel struct {
+	Character types.String `tfsdk:"character"`
+	Quote     types.String `tfsdk:"quote"`
+}
+
+func (d *ScenesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scenes"
+}
+
+func (d *ScenesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fetches the scenes of an episode",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"season": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number of the episode. Required unless the provider sets default_season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"episode": schema.Int64Attribute{
+				Required:    true,
+				Description: "Episode number within the season",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
+			},
+			"episode_name": schema.StringAttribute{
+				Description: "The name of the episode.",
+				Computed:    true,
+			},
+			"scenes": schema.ListNestedAttribute{
+				Description: "List of scenes in the order they occur",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scene": schema.Int64Attribute{
+							Description: "The scene number.",
+							Computed:    true,
+						},
+						"lines": schema.ListNestedAttribute{
+							Description: "The lines of the scene in the order they were spoken",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"character": schema.StringAttribute{
+										Description: "The character who said the line.",
+										Computed:    true,
+									},
+									"quote": schema.StringAttribute{
+										Description: "The line as a string",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"characters": schema.ListAttribute{
+							Description: "The characters who speak in the scene, in the order they first speak.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"line_count": schema.Int64Attribute{
+							Description: "The number of lines in the scene.",
+							Computed:    true,
+						},
+						"word_count": schema.Int64Attribute{
+							Description: "The number of words spoken in the scene.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ScenesDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
+	}
+}
+
+func (d *ScenesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *ScenesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ScenesDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	quotes, err := d.providerData.getQuotes(ctx, int(data.Season.ValueInt64()), int(data.Episode.ValueInt64()), &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read theOffice Scenes",
+			err.Error(),
+		)
+		return
+	}
+
+	data.EpisodeName = types.StringNull()
+	for _, scene := range theoffice.GroupScenes(quotes) {
+		data.EpisodeName = types.StringValue(scene.EpisodeName)
+
+		sceneState := scenesModel{
+			Scene:     types.Int64Value(int64(scene.Number)),
+			LineCount: types.Int64Value(int64(len(scene.Lines))),
+			WordCount: types.Int64Value(int64(scene.WordCount)),
+		}
+
+		for _, line := range scene.Lines {
+			sceneState.Lines = append(sceneState.Lines, sceneLinesModel{
+				Character: types.StringValue(line.Character),
+				Quote:     types.StringValue(line.Quote),
+			})
+		}
+
+		for _, character := range scene.Characters {
+			sceneState.Characters = append(sceneState.Characters, types.StringValue(character))
+		}
+
+		data.Scenes = append(data.Scenes, sceneState)
+	}
+
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read scenes data source")
+}
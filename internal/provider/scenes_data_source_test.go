@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccScenesDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccScenesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.theoffice_scenes.test", "episode_name"),
+					resource.TestCheckResourceAttrSet("data.theoffice_scenes.test", "scenes.#"),
+					resource.TestCheckResourceAttrSet("data.theoffice_scenes.test", "scenes.0.lines.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccScenesDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccScenesDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "episode_name", "The Dundies"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.#", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.0.scene", "1"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.0.line_count", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.0.word_count", "25"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.0.lines.1.character", "Dwight"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.2.characters.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.2.characters.0", "Angela"),
+					resource.TestCheckResourceAttr("data.theoffice_scenes.test", "scenes.2.characters.1", "Oscar"),
+				),
+			},
+		},
+	})
+}
+
+const testAccScenesDataSourceConfig = `
+data "theoffice_scenes" "test" {
+  season  = 1
+  episode = 1
+}
+`
+
+func testAccScenesDataSourceConfig_stubServer(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_scenes" "test" {
+  season  = 2
+  episode = 1
+}
+`, endpoint)
+}
@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &SimilarQuotesDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &SimilarQuotesDataSource{}
+)
+
+func NewSimilarQuotesDataSource() datasource.DataSource {
+	return &SimilarQuotesDataSource{}
+}
+
+// SimilarQuotesDataSource defines the data source implementation.
+type SimilarQuotesDataSource struct {
+	providerData *providerData
+}
+
+// SimilarQuotesDataSourceModel describes the data source data model.
+type SimilarQuotesDataSourceModel struct {
+	Text              types.String         `tfsdk:"text"`
+	Quote             *quoteCoordsModel    `tfsdk:"quote"`
+	Season            types.Int64          `tfsdk:"season"`
+	Episode           types.Int64          `tfsdk:"episode"`
+	Characters        []types.String       `tfsdk:"characters"`
+	ExcludeCharacters []types.String       `tfsdk:"exclude_characters"`
+	Limit             types.Int64          `tfsdk:"limit"`
+	MinScore          types.Float64        `tfsdk:"min_score"`
+	Results           []searchResultsModel `tfsdk:"results"`
+	ID                types.String         `tfsdk:"id"`
+}
+
+type quoteCoordsModel struct {
+	Season    types.Int64  `tfsdk:"season"`
+	Episode   types.Int64  `tfsdk:"episode"`
+	Scene     types.Int64  `tfsdk:"scene"`
+	Character types.String `tfsdk:"character"`
+}
+
+func (d *SimilarQuotesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_similar_quotes"
+}
+
+func (d *SimilarQuotesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Finds the quotes most similar to a given text or quote, by cosine similarity of their TF-IDF vectors",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"text": schema.StringAttribute{
+				Optional:    true,
+				Description: "The text to find similar quotes to. Exactly one of text or quote must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("quote")),
+				},
+			},
+			"quote": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The quote to find similar quotes to, identified by its coordinates. When the character " +
+					"speaks several lines in the scene, they are searched for together. These lines are excluded from the results.",
+				Attributes: map[string]schema.Attribute{
+					"season": schema.Int64Attribute{
+						Required:    true,
+						Description: "The season the quote occurred in.",
+						Validators: []validator.Int64{
+							seasonValidator(),
+						},
+					},
+					"episode": schema.Int64Attribute{
+						Required:    true,
+						Description: "The episode the quote occurred in.",
+						Validators: []validator.Int64{
+							episodeValidator(),
+						},
+					},
+					"scene": schema.Int64Attribute{
+						Required:    true,
+						Description: "The scene the quote occurred in.",
+					},
+					"character": schema.StringAttribute{
+						Required:    true,
+						Description: "The character who said the quote, case-insensitively.",
+					},
+				},
+			},
+			"season": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number to search. Defaults to the provider's default_season, or every season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"episode": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Episode number to search. Requires season, or the provider's default_season.",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
+			},
+			"characters":         charactersAttribute("similar quotes"),
+			"exclude_characters": excludeCharactersAttribute("similar quotes"),
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of results (default: %d)", theoffice.DefaultSimilarLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_score": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The minimum similarity, from 0 to 1, of the results",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "List of similar quotes, ordered by descending score",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"season": schema.Int64Attribute{
+							Description: "The season the quote occurred in.",
+							Computed:    true,
+						},
+						"episode": schema.Int64Attribute{
+							Description: "The episode the quote occurred in.",
+							Computed:    true,
+						},
+						"scene": schema.Int64Attribute{
+							Description: "The scene the quote occurred in.",
+							Computed:    true,
+						},
+						"episode_name": schema.StringAttribute{
+							Description: "The name of the episode the quote occurred in.",
+							Computed:    true,
+						},
+						"character": schema.StringAttribute{
+							Description: "The character who said the quote.",
+							Computed:    true,
+						},
+						"quote": schema.StringAttribute{
+							Description: "The quote as a string",
+							Computed:    true,
+						},
+						"score": schema.Float64Attribute{
+							Description: "The cosine similarity, from 0 to 1, of the quote.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SimilarQuotesDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
+		seasonEpisodeValidator{
+			season:  path.Root("quote").AtName("season"),
+			episode: path.Root("quote").AtName("episode"),
+		},
+	}
+}
+
+func (d *SimilarQuotesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *SimilarQuotesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SimilarQuotesDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope := d.providerData.settings.characterScope(data.Characters, data.ExcludeCharacters)
+
+	// Read Terraform configuration data into the model
+	text := data.Text.ValueString()
+	exclude := func(q theoffice.Quote) bool {
+		return !scope.allowsQuote(q)
+	}
+	if data.Quote != nil {
+		source, err := d.readSourceQuotes(ctx, data.Quote, &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read theOffice Quotes",
+				err.Error(),
+			)
+			return
+		}
+		if len(source) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("quote"),
+				"theOffice Quote Not Found",
+				fmt.Sprintf("%s has no lines in scene %d of season %d, episode %d.", data.Quote.Character.ValueString(),
+					data.Quote.Scene.ValueInt64(), data.Quote.Season.ValueInt64(), data.Quote.Episode.ValueInt64()),
+			)
+			return
+		}
+
+		var lines []string
+		for _, q := range source {
+			lines = append(lines, q.Quote)
+		}
+		text = strings.Join(lines, " ")
+
+		// Every line of the source is left out, including those said along
+		// with other characters.
+		outOfScope := exclude
+		inSource := d.sourceQuote(data.Quote)
+		exclude = func(q theoffice.Quote) bool {
+			return outOfScope(q) || inSource(q)
+		}
+	}
+
+	idx, err := d.providerData.quoteIndex(ctx, data.Season, data.Episode, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read theOffice Quotes",
+			err.Error(),
+		)
+		return
+	}
+
+	results := idx.Similar(text, theoffice.SimilarOptions{
+		Limit:    int(data.Limit.ValueInt64()),
+		MinScore: data.MinScore.ValueFloat64(),
+		Exclude:  exclude,
+	})
+
+	data.Results = []searchResultsModel{}
+	for _, r := range results {
+		data.Results = append(data.Results, searchResultsModel{
+			Season:      types.Int64Value(int64(r.Quote.Season)),
+			Episode:     types.Int64Value(int64(r.Quote.Episode)),
+			Scene:       types.Int64Value(int64(r.Quote.Scene)),
+			EpisodeName: types.StringValue(r.Quote.EpisodeName),
+			Character:   types.StringValue(r.Quote.Character),
+			Quote:       types.StringValue(r.Quote.Quote),
+			Score:       types.Float64Value(r.Score),
+		})
+	}
+
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read similar quotes data source")
+}
+
+// readSourceQuotes returns the lines the character speaks in the scene
+// identified by coords.
+func (d *SimilarQuotesDataSource) readSourceQuotes(ctx context.Context, coords *quoteCoordsModel, diags *diag.Diagnostics) ([]theoffice.Quote, error) {
+	quotes, err := d.providerData.getQuotes(ctx, int(coords.Season.ValueInt64()), int(coords.Episode.ValueInt64()), diags)
+	if err != nil {
+		return nil, err
+	}
+
+	inSource := d.sourceQuote(coords)
+	var source []theoffice.Quote
+	for _, q := range quotes {
+		if inSource(q) {
+			source = append(source, q)
+		}
+	}
+
+	return source, nil
+}
+
+// sourceQuote returns a function reporting whether a quote is one of the
+// lines the character speaks in the scene identified by coords.
+func (d *SimilarQuotesDataSource) sourceQuote(coords *quoteCoordsModel) func(theoffice.Quote) bool {
+	character := d.providerData.settings.characterNames.Normalize(coords.Character.ValueString())
+	saidBy := func(speaker string) bool {
+		return strings.EqualFold(speaker, character)
+	}
+
+	return func(q theoffice.Quote) bool {
+		return q.Season == int(coords.Season.ValueInt64()) && q.Episode == int(coords.Episode.ValueInt64()) &&
+			q.Scene == int(coords.Scene.ValueInt64()) && (saidBy(q.Character) || slices.ContainsFunc(q.SpeakerNames(), saidBy))
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSimilarQuotesDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSimilarQuotesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.theoffice_similar_quotes.test", "results.#"),
+					resource.TestCheckResourceAttrSet("data.theoffice_similar_quotes.test", "results.0.score"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSimilarQuotesDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSimilarQuotesDataSourceConfig_stubServer(srv.URL, `text = "Everybody hates parties and an award"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.#", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.0.character", "Michael"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.0.score", "0.4465"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.1.quote", "I hate parties."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSimilarQuotesDataSource_quote(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccSimilarQuotesDataSourceConfig_quote(srv.URL, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.0.quote", "Question. Can I be a ninja?"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.0.scene", "3"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.0.score", "0.3671"),
+				),
+			},
+			{
+				Config:      testAccSimilarQuotesDataSourceConfig_quote(srv.URL, 9),
+				ExpectError: regexp.MustCompile("theOffice Quote Not Found"),
+			},
+		},
+	})
+}
+
+func TestAccSimilarQuotesDataSource_quoteSaidTogether(t *testing.T) {
+	dataset := theofficetest.DefaultDataset()
+	for i, q := range dataset.Quotes {
+		if q.Character == "Stanley" {
+			dataset.Quotes[i].Character = "Dwight & Stanley"
+		}
+	}
+	srv := theofficetest.NewServer(theofficetest.WithDataset(dataset))
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Every line of the source is left out of the results, including
+			// those said along with other characters.
+			{
+				Config: testAccSimilarQuotesDataSourceConfig_quote(srv.URL, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.0.quote", "Question. Can I be a ninja?"),
+					resource.TestCheckResourceAttr("data.theoffice_similar_quotes.test", "results.1.character", "Michael"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSimilarQuotesDataSource_invalidEpisode(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "theoffice_similar_quotes" "test" {
+  quote = {
+    season    = 4
+    episode   = 20
+    scene     = 1
+    character = "Michael"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Season 4 has 14 episodes, got episode 20`),
+			},
+		},
+	})
+}
+
+const testAccSimilarQuotesDataSourceConfig = `
+data "theoffice_similar_quotes" "test" {
+  season = 2
+  text   = "I would like an award"
+  limit  = 5
+}
+`
+
+func testAccSimilarQuotesDataSourceConfig_stubServer(endpoint, source string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_similar_quotes" "test" {
+  season = 2
+  limit  = 3
+  %[2]s
+}
+`, endpoint, source)
+}
+
+func testAccSimilarQuotesDataSourceConfig_quote(endpoint string, scene int) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_similar_quotes" "test" {
+  season = 1
+
+  quote = {
+    season    = 1
+    episode   = 2
+    scene     = %[2]d
+    character = "dwight"
+  }
+}
+`, endpoint, scene)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &TranscriptDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &TranscriptDataSource{}
+)
+
+func NewTranscriptDataSource() datasource.DataSource {
+	return &TranscriptDataSource{}
+}
+
+// TranscriptDataSource defines the data source implementation.
+type TranscriptDataSource struct {
+	providerData *providerData
+}
+
+// TranscriptDataSourceModel describes the data source data model.
+type TranscriptDataSourceModel struct {
+	Season      types.Int64  `tfsdk:"season"`
+	Episode     types.Int64  `tfsdk:"episode"`
+	Format      types.String `tfsdk:"format"`
+	Width       types.Int64  `tfsdk:"width"`
+	EpisodeName types.String `tfsdk:"episode_name"`
+	Transcript  types.String `tfsdk:"transcript"`
+	ID          types.String `tfsdk:"id"`
+}
+
+func (d *TranscriptDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transcript"
+}
+
+func (d *TranscriptDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Renders the transcript of an episode",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"season": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Season number of the episode. Required unless the provider sets default_season.",
+				Validators: []validator.Int64{
+					seasonValidator(),
+				},
+			},
+			"episode": schema.Int64Attribute{
+				Required:    true,
+				Description: "Episode number within the season",
+				Validators: []validator.Int64{
+					episodeValidator(),
+				},
+			},
+			"format": schema.StringAttribute{
+				Optional: true,
+				Description: "The format to render the transcript in: \"fountain\" (default) for a Fountain screenplay, " +
+					"\"markdown\" or \"text\"",
+				Validators: []validator.String{
+					stringvalidator.OneOf(theoffice.TranscriptFormats...),
+				},
+			},
+			"width": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of columns to wrap dialogue at. Dialogue is not wrapped by default.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"episode_name": schema.StringAttribute{
+				Description: "The name of the episode.",
+				Computed:    true,
+			},
+			"transcript": schema.StringAttribute{
+				Description: "The rendered transcript.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *TranscriptDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		seasonEpisodeValidator{season: path.Root("season"), episode: path.Root("episode")},
+	}
+}
+
+func (d *TranscriptDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.providerData = configureProviderData(req.ProviderData, "Data Source", &resp.Diagnostics)
+}
+
+func (d *TranscriptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TranscriptDataSourceModel
+
+	if deferUnknownRead(ctx, req, resp) {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Season = d.providerData.settings.resolveSeason(data.Season, data.Episode, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	quotes, err := d.providerData.getQuotes(ctx, int(data.Season.ValueInt64()), int(data.Episode.ValueInt64()), &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read theOffice Transcript",
+			err.Error(),
+		)
+		return
+	}
+
+	scenes := theoffice.GroupScenes(quotes)
+	transcript, err := theoffice.RenderTranscript(scenes, theoffice.TranscriptOptions{
+		Format: data.Format.ValueString(),
+		Width:  int(data.Width.ValueInt64()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Render theOffice Transcript",
+			err.Error(),
+		)
+		return
+	}
+
+	data.EpisodeName = types.StringNull()
+	if len(scenes) > 0 {
+		data.EpisodeName = types.StringValue(scenes[0].EpisodeName)
+	}
+	data.Transcript = types.StringValue(transcript)
+	data.ID = types.StringValue("placeholder")
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read transcript data source")
+}
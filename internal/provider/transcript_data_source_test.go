@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theofficetest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTranscriptDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithCassette(t),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTranscriptDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.theoffice_transcript.test", "episode_name"),
+					resource.TestMatchResourceAttr("data.theoffice_transcript.test", "transcript", regexp.MustCompile(`^Title: `)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTranscriptDataSource_stubServer(t *testing.T) {
+	srv := theofficetest.NewServer()
+	defer srv.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTranscriptDataSourceConfig_stubServer(srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.theoffice_transcript.test", "episode_name", "The Dundies"),
+					resource.TestMatchResourceAttr("data.theoffice_transcript.test", "transcript", regexp.MustCompile(`^# The Dundies\n`)),
+					resource.TestMatchResourceAttr("data.theoffice_transcript.test", "transcript", regexp.MustCompile(`\n## Scene 3\n\n\*\*Angela:\*\* This is a disgrace.\n`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccTranscriptDataSourceConfig = `
+data "theoffice_transcript" "test" {
+  season  = 1
+  episode = 1
+}
+`
+
+func testAccTranscriptDataSourceConfig_stubServer(endpoint string) string {
+	return fmt.Sprintf(`
+provider "theoffice" {
+  endpoint = %[1]q
+}
+
+data "theoffice_transcript" "test" {
+  season  = 2
+  episode = 1
+  format  = "markdown"
+  width   = 60
+}
+`, endpoint)
+}
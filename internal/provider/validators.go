@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// seasonValidator checks that a season exists in theoffice catalogue.
+func seasonValidator() validator.Int64 {
+	return int64validator.Between(1, theoffice.NumSeasons)
+}
+
+// episodeValidator checks that an episode number exists in at least one
+// season. seasonEpisodeValidator checks it against the configured season.
+func episodeValidator() validator.Int64 {
+	return int64validator.Between(1, theoffice.MaxEpisodes)
+}
+
+var _ datasource.ConfigValidator = seasonEpisodeValidator{}
+
+// seasonEpisodeValidator checks that the episode at one path exists in the
+// season at another, according to theoffice catalogue.
+type seasonEpisodeValidator struct {
+	season  path.Path
+	episode path.Path
+}
+
+func (v seasonEpisodeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s must be an episode of the season set in %s", v.episode, v.season)
+}
+
+func (v seasonEpisodeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v seasonEpisodeValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var season, episode types.Int64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.season, &season)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.episode, &episode)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that aren't known yet are validated once they are, and out of
+	// range seasons are reported by seasonValidator.
+	if season.IsNull() || season.IsUnknown() || episode.IsNull() || episode.IsUnknown() {
+		return
+	}
+	numEpisodes := theoffice.NumEpisodes(int(season.ValueInt64()))
+	if numEpisodes == 0 {
+		return
+	}
+
+	if !theoffice.ValidEpisode(int(season.ValueInt64()), int(episode.ValueInt64())) {
+		resp.Diagnostics.AddAttributeError(
+			v.episode,
+			"Invalid theOffice Episode",
+			fmt.Sprintf("Season %d has %d episodes, got episode %d.", season.ValueInt64(), numEpisodes, episode.ValueInt64()),
+		)
+	}
+}
+
+var _ datasource.ConfigValidator = trueConflictsWithValidator{}
+
+// trueConflictsWithValidator checks that the attribute at one path is null
+// when the bool at another is true. Unlike boolvalidator.ConflictsWith, a
+// false bool doesn't conflict.
+type trueConflictsWithValidator struct {
+	flag        path.Path
+	conflicting path.Path
+}
+
+func (v trueConflictsWithValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s cannot be set when %s is true", v.conflicting, v.flag)
+}
+
+func (v trueConflictsWithValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v trueConflictsWithValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var flag types.Bool
+	var conflicting attr.Value
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.flag, &flag)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.conflicting, &conflicting)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !flag.ValueBool() || conflicting.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		v.conflicting,
+		"Invalid Attribute Combination",
+		fmt.Sprintf("Attribute %q cannot be specified when %q is true.", v.conflicting, v.flag),
+	)
+}
+
+var _ datasource.ConfigValidator = trueConflictsWithValueValidator{}
+
+// trueConflictsWithValueValidator checks that the string at one path isn't
+// value when the bool at another is true, such as for values that can't
+// honor the bool.
+type trueConflictsWithValueValidator struct {
+	flag        path.Path
+	conflicting path.Path
+	value       string
+}
+
+func (v trueConflictsWithValueValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s cannot be %q when %s is true", v.conflicting, v.value, v.flag)
+}
+
+func (v trueConflictsWithValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v trueConflictsWithValueValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var flag types.Bool
+	var conflicting types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.flag, &flag)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.conflicting, &conflicting)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !flag.ValueBool() || conflicting.IsUnknown() || conflicting.ValueString() != v.value {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		v.conflicting,
+		"Invalid Attribute Combination",
+		fmt.Sprintf("Attribute %q cannot be %q when %q is true.", v.conflicting, v.value, v.flag),
+	)
+}
+
+var _ datasource.ConfigValidator = requiresOneOfValidator{}
+
+// requiresOneOfValidator checks that the string at one path is one of values
+// when any of the attributes at other paths is set, such as for attributes
+// that only take effect with some of its values. A null string doesn't
+// match any value.
+type requiresOneOfValidator struct {
+	attributes []path.Path
+	required   path.Path
+	values     []string
+}
+
+func (v requiresOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s must be one of %q when any of %s is set", v.required, v.values, v.attributes)
+}
+
+func (v requiresOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v requiresOneOfValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var required types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.required, &required)...)
+	if resp.Diagnostics.HasError() || required.IsUnknown() || slices.Contains(v.values, required.ValueString()) {
+		return
+	}
+
+	for _, p := range v.attributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Attribute %q only takes effect when %q is one of %q.", p, v.required, v.values),
+			)
+		}
+	}
+}
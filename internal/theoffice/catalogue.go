@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+// NumSeasons is the number of seasons of the show.
+const NumSeasons = 9
+
+// MaxEpisodes is the number of episodes of the longest season.
+const MaxEpisodes = 26
+
+// episodes is the number of episodes of each season, as served by the API.
+// Two-part episodes count as one.
+var episodes = [NumSeasons]int{6, 22, 23, 14, 26, 24, 24, 24, 23}
+
+// NumEpisodes returns the number of episodes of season, or zero if the show
+// has no such season.
+func NumEpisodes(season int) int {
+	if season < 1 || season > NumSeasons {
+		return 0
+	}
+
+	return episodes[season-1]
+}
+
+// ValidEpisode reports whether the show has the given episode of season.
+func ValidEpisode(season, episode int) bool {
+	return episode >= 1 && episode <= NumEpisodes(season)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumEpisodes(t *testing.T) {
+	assert.Equal(t, 6, NumEpisodes(1))
+	assert.Equal(t, 23, NumEpisodes(NumSeasons))
+	assert.Equal(t, 0, NumEpisodes(0))
+	assert.Equal(t, 0, NumEpisodes(NumSeasons+1))
+
+	longest := 0
+	for s := 1; s <= NumSeasons; s++ {
+		longest = max(longest, NumEpisodes(s))
+	}
+	assert.Equal(t, MaxEpisodes, longest)
+}
+
+func TestValidEpisode(t *testing.T) {
+	assert.True(t, ValidEpisode(1, 1))
+	assert.True(t, ValidEpisode(1, 6))
+	assert.False(t, ValidEpisode(1, 7))
+	assert.True(t, ValidEpisode(5, 26))
+	assert.False(t, ValidEpisode(1, 0))
+	assert.False(t, ValidEpisode(42, 1))
+}
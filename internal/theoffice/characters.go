@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import "strings"
+
+// characterAliases maps the spellings of character names found in the API's
+// dataset, in lowercase, to the name the dataset uses most, which is usually
+// the character's first name.
+var characterAliases = map[string]string{
+	"andy":              "Andy",
+	"andy bernard":      "Andy",
+	"angela":            "Angela",
+	"angela martin":     "Angela",
+	"creed":             "Creed",
+	"creed bratton":     "Creed",
+	"darryl":            "Darryl",
+	"darryl philbin":    "Darryl",
+	"david":             "David",
+	"david wallace":     "David",
+	"dwight":            "Dwight",
+	"dwight schrute":    "Dwight",
+	"erin":              "Erin",
+	"erin hannon":       "Erin",
+	"gabe":              "Gabe",
+	"gabe lewis":        "Gabe",
+	"holly":             "Holly",
+	"holly flax":        "Holly",
+	"jan":               "Jan",
+	"jan levinson":      "Jan",
+	"jim":               "Jim",
+	"jim halpert":       "Jim",
+	"karen":             "Karen",
+	"karen filippelli":  "Karen",
+	"kelly":             "Kelly",
+	"kelly kapoor":      "Kelly",
+	"kevin":             "Kevin",
+	"kevin malone":      "Kevin",
+	"meredith":          "Meredith",
+	"meredith palmer":   "Meredith",
+	"michael":           "Michael",
+	"michael scott":     "Michael",
+	"nellie":            "Nellie",
+	"nellie bertram":    "Nellie",
+	"oscar":             "Oscar",
+	"oscar martinez":    "Oscar",
+	"pam":               "Pam",
+	"pam beesly":        "Pam",
+	"pam halpert":       "Pam",
+	"phyllis":           "Phyllis",
+	"phyllis lapin":     "Phyllis",
+	"phyllis vance":     "Phyllis",
+	"robert":            "Robert",
+	"robert california": "Robert",
+	"roy":               "Roy",
+	"roy anderson":      "Roy",
+	"ryan":              "Ryan",
+	"ryan howard":       "Ryan",
+	"stanley":           "Stanley",
+	"stanley hudson":    "Stanley",
+	"toby":              "Toby",
+	"toby flenderson":   "Toby",
+}
+
+// CharacterNames normalizes the names of characters, so that a character
+// spelled several ways in the dataset is aggregated under a single name. A
+// nil *CharacterNames leaves names as they are.
+type CharacterNames struct {
+	aliases map[string]string
+	builtin map[string]string
+}
+
+// NewCharacterNames returns a CharacterNames that maps names with aliases,
+// which map names to the name to use instead. With builtin, names are mapped
+// with the built-in alias table first, and aliases may rename the built-in
+// names too. Names are matched ignoring case and extra whitespace.
+func NewCharacterNames(aliases map[string]string, builtin bool) *CharacterNames {
+	n := &CharacterNames{aliases: make(map[string]string, len(aliases))}
+	for alias, name := range aliases {
+		n.aliases[characterKey(alias)] = name
+	}
+	if builtin {
+		n.builtin = characterAliases
+	}
+
+	return n
+}
+
+// characterKey returns the key name is matched by.
+func characterKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Normalize returns the name to use for the character named name.
+func (n *CharacterNames) Normalize(name string) string {
+	if n == nil {
+		return name
+	}
+
+	key := characterKey(name)
+	if alias, ok := n.aliases[key]; ok {
+		return alias
+	}
+
+	normalized, ok := n.builtin[key]
+	if !ok {
+		return strings.Join(strings.Fields(name), " ")
+	}
+	if alias, ok := n.aliases[characterKey(normalized)]; ok {
+		return alias
+	}
+
+	return normalized
+}
+
+// Quotes returns a copy of quotes with normalized character names.
+func (n *CharacterNames) Quotes(quotes []Quote) []Quote {
+	if n == nil {
+		return quotes
+	}
+
+	normalized := make([]Quote, len(quotes))
+	for i, q := range quotes {
+		q.Character = n.Normalize(q.Character)
+		normalized[i] = q
+	}
+
+	return normalized
+}
+
+// Connections returns a copy of connections with normalized character names.
+// Links and nodes that come to name the same characters are merged, summing
+// the values of links, and links that come to join a character to itself are
+// dropped.
+func (n *CharacterNames) Connections(connections []Connection) []Connection {
+	if n == nil {
+		return connections
+	}
+
+	return renameConnections(connections, func(name string) []string {
+		return []string{n.Normalize(name)}
+	})
+}
+
+// renameConnections returns a copy of connections where each character is
+// replaced by those rename returns. Links and nodes that come to name the
+// same characters are merged, summing the values of links, and links that
+// come to join a character to itself are dropped.
+func renameConnections(connections []Connection, rename func(string) []string) []Connection {
+	renamed := make([]Connection, len(connections))
+	for i, conn := range connections {
+		conn.Links = renameLinks(conn.Links, rename)
+		conn.Nodes = renameNodes(conn.Nodes, rename)
+		renamed[i] = conn
+	}
+
+	return renamed
+}
+
+func renameLinks(links []Link, rename func(string) []string) []Link {
+	var renamed []Link
+	index := map[[2]string]int{}
+	for _, l := range links {
+		for _, source := range rename(l.Source) {
+			for _, target := range rename(l.Target) {
+				if source == target && l.Source != l.Target {
+					continue
+				}
+
+				k := [2]string{source, target}
+				if i, ok := index[k]; ok {
+					renamed[i].Value += l.Value
+					continue
+				}
+				index[k] = len(renamed)
+				renamed = append(renamed, Link{Source: source, Target: target, Value: l.Value})
+			}
+		}
+	}
+
+	return renamed
+}
+
+func renameNodes(nodes []Node, rename func(string) []string) []Node {
+	var renamed []Node
+	seen := map[string]bool{}
+	for _, node := range nodes {
+		for _, id := range rename(node.ID) {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			renamed = append(renamed, Node{ID: id})
+		}
+	}
+
+	return renamed
+}
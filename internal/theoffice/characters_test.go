@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharacterNames_Normalize(t *testing.T) {
+	n := NewCharacterNames(map[string]string{
+		"Mike":     "Michael",
+		"darryl":   "Darryl Philbin",
+		"Todd ":    "Todd Packer",
+		"Michael ": "Michael",
+	}, true)
+
+	for name, want := range map[string]string{
+		"Michael":         "Michael",
+		"Michael Scott":   "Michael",
+		"MIchael":         "Michael",
+		" michael  scott": "Michael",
+		"David Wallace":   "David",
+		"Mike":            "Michael",
+		"Darryl":          "Darryl Philbin",
+		"Darryl Philbin":  "Darryl Philbin",
+		"todd":            "Todd Packer",
+		"Bob  Vance":      "Bob Vance",
+		"":                "",
+	} {
+		assert.Equal(t, want, n.Normalize(name), name)
+	}
+
+	var unset *CharacterNames
+	assert.Equal(t, "MIchael", unset.Normalize("MIchael"))
+}
+
+func TestCharacterNames_Normalize_withoutBuiltin(t *testing.T) {
+	n := NewCharacterNames(map[string]string{"Mike": "Michael"}, false)
+
+	for name, want := range map[string]string{
+		"Michael":         "Michael",
+		"Michael Scott":   "Michael Scott",
+		" michael  scott": "michael scott",
+		"mike":            "Michael",
+	} {
+		assert.Equal(t, want, n.Normalize(name), name)
+	}
+}
+
+func TestCharacterNames_Quotes(t *testing.T) {
+	quotes := []Quote{
+		{Season: 1, Episode: 1, Scene: 1, Character: "Michael Scott", Quote: "Hi."},
+		{Season: 1, Episode: 1, Scene: 1, Character: "Jim", Quote: "Hey."},
+	}
+
+	normalized := NewCharacterNames(nil, true).Quotes(quotes)
+	assert.Equal(t, "Michael", normalized[0].Character)
+	assert.Equal(t, "Jim", normalized[1].Character)
+	// The quotes given are left as they are.
+	assert.Equal(t, "Michael Scott", quotes[0].Character)
+}
+
+func TestCharacterNames_Connections(t *testing.T) {
+	connections := []Connection{{
+		Episode:     1,
+		EpisodeName: "Pilot",
+		Links: []Link{
+			{Source: "Jim", Target: "Michael", Value: 4},
+			{Source: "Jim", Target: "Michael Scott", Value: 2},
+			{Source: "Michael", Target: "MIchael", Value: 1},
+			{Source: "Pam", Target: "Jim", Value: 3},
+			{Source: "Pam", Target: "Pam", Value: 1},
+		},
+		Nodes: []Node{{ID: "Jim"}, {ID: "Michael"}, {ID: "Michael Scott"}, {ID: "Pam"}},
+	}}
+
+	normalized := NewCharacterNames(nil, true).Connections(connections)
+	assert.Equal(t, []Link{
+		{Source: "Jim", Target: "Michael", Value: 6},
+		{Source: "Pam", Target: "Jim", Value: 3},
+		// Self-loops from the API are kept.
+		{Source: "Pam", Target: "Pam", Value: 1},
+	}, normalized[0].Links)
+	assert.Equal(t, []Node{{ID: "Jim"}, {ID: "Michael"}, {ID: "Pam"}}, normalized[0].Nodes)
+	assert.Equal(t, "Pilot", normalized[0].EpisodeName)
+	assert.Equal(t, 5, len(connections[0].Links))
+}
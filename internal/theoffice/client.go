@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -23,32 +25,91 @@ const (
 	defaultRetryWaitMax = 30 * time.Second
 )
 
+// Doer sends an HTTP request and returns its response. It is satisfied by
+// *http.Client and lets callers swap out how a Client reaches the API.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 type Config struct {
 	Address string
+
+	// Addresses, when set, lists the API endpoints in order of preference,
+	// and Address is ignored. Requests go to the first healthy endpoint and
+	// fail over to the next on connection errors and server errors.
+	Addresses []string
+
+	// HealthCheckInterval is how long an endpoint that failed is skipped
+	// before it is probed again. Defaults to 30 seconds.
+	HealthCheckInterval time.Duration
+
+	// Transport, when set, is used beneath the default retrying HTTP client
+	// in place of the standard pooled transport.
+	Transport http.RoundTripper
+
+	// Doer, when set, replaces the default retrying HTTP client entirely.
+	// Transport is ignored in that case.
+	Doer Doer
+
+	// UserAgent, when set, is sent in the User-Agent header of every request.
+	UserAgent string
+
+	// StrictDecoding checks responses against the API's schema: unknown
+	// fields, missing required fields and values out of range are reported
+	// in a *SchemaError, returned along with the response.
+	StrictDecoding bool
 }
 
 type Client struct {
-	baseURL    string
-	httpClient *retryablehttp.Client
+	endpoints      *endpoints
+	doer           Doer
+	userAgent      string
+	strictDecoding bool
 }
 
 func NewClient(config *Config) (*Client, error) {
-	if config.Address == "" {
-		config.Address = defaultAddress
+	addresses := config.Addresses
+	if len(addresses) == 0 {
+		if config.Address == "" {
+			config.Address = defaultAddress
+		}
+		addresses = []string{config.Address}
 	}
 
-	if _, err := url.Parse(config.Address); err != nil {
-		return nil, fmt.Errorf("invalid address %q: %w", config.Address, err)
+	for _, address := range addresses {
+		if _, err := url.Parse(address); err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", address, err)
+		}
 	}
 
+	doer := config.Doer
+	if doer == nil {
+		doer = newRetryableDoer(config.Transport, len(addresses) > 1)
+	}
+
+	return &Client{
+		endpoints:      newEndpoints(addresses, config.HealthCheckInterval),
+		doer:           doer,
+		userAgent:      config.UserAgent,
+		strictDecoding: config.StrictDecoding,
+	}, nil
+}
+
+// newRetryableDoer returns the default Doer: an *http.Client that retries
+// failed requests, optionally sending them through transport. With failover,
+// failures that the next endpoint may not return aren't retried.
+func newRetryableDoer(transport http.RoundTripper, failover bool) Doer {
 	client := retryablehttp.NewClient()
 	client.RetryWaitMax = defaultRetryWaitMax
 	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	if failover {
+		client.CheckRetry = failoverRetryPolicy
+	}
+	if transport != nil {
+		client.HTTPClient.Transport = transport
+	}
 
-	return &Client{
-		baseURL:    config.Address,
-		httpClient: client,
-	}, nil
+	return client.StandardClient()
 }
 
 type ConnectionsResponse struct {
@@ -83,6 +144,10 @@ type Quote struct {
 	EpisodeName string `json:"episode_name,omitempty"`
 	Character   string `json:"character,omitempty"`
 	Quote       string `json:"quote,omitempty"`
+
+	// Speakers are the characters saying the quote, parsed from Character
+	// by a SpeakerParser. They aren't part of the API's responses.
+	Speakers []string `json:"-"`
 }
 
 func (c *Client) GetConnections(ctx context.Context, season int) (*ConnectionsResponse, error) {
@@ -90,6 +155,9 @@ func (c *Client) GetConnections(ctx context.Context, season int) (*ConnectionsRe
 
 	resp := &ConnectionsResponse{}
 	err := c.do(ctx, "GET", path, nil, &resp.Connections)
+	if c.strictDecoding {
+		err = withViolations(err, path, validateConnections(resp.Connections, season))
+	}
 	return resp, err
 }
 
@@ -102,45 +170,132 @@ func (c *Client) GetQuotes(ctx context.Context, season, episode int) (*QuotesRes
 	}
 	resp := &QuotesResponse{}
 	err := c.do(ctx, "GET", path, nil, &resp.Quotes)
+	if c.strictDecoding {
+		err = withViolations(err, path, validateQuotes(resp.Quotes, season, episode))
+	}
 	return resp, err
 }
 
+// do sends the request to the first healthy endpoint, failing over to the
+// next ones in order. When every endpoint failed too recently to be tried,
+// the one that failed least recently is tried anyway, so that a brief outage
+// of every endpoint doesn't fail requests until they are probed again.
 func (c *Client) do(ctx context.Context, method, path string, rq, resp any) error {
-	f := func() error {
-		logger := hclog.FromContext(ctx).Named("theoffice_client")
-		ctx = hclog.WithContext(ctx, logger)
-		url := fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(path, "/"))
-		var body io.Reader
-		if rq != nil {
-			var buf bytes.Buffer
-			if err := json.NewEncoder(&buf).Encode(rq); err != nil {
-				return fmt.Errorf("encoding request: %w", err)
+	logger := hclog.FromContext(ctx).Named("theoffice_client")
+	ctx = hclog.WithContext(ctx, logger)
+	failover := len(c.endpoints.list) > 1
+
+	var errs []error
+	// try sends the request to ep, and reports whether its result is final.
+	try := func(ep *endpoint) (bool, error) {
+		err := c.doAt(ctx, ep.address, method, path, rq, resp)
+
+		var failoverErr *failoverError
+		if !errors.As(err, &failoverErr) {
+			if err == nil {
+				logger.Info("request served", "endpoint", ep.address, "method", method, "path", path)
 			}
-			body = &buf
+			return true, err
 		}
-		req, err := retryablehttp.NewRequest(method, url, body)
-		if err != nil {
-			return fmt.Errorf("constructing http request: %w", err)
+		if !failover || ctx.Err() != nil {
+			return true, failoverErr.err
 		}
-		req.Header.Set("Content-Type", "application/json")
 
-		logger.Debug("making http request", "method", method, "url", url)
-		res, err := c.httpClient.Do(req)
-		if err != nil {
+		c.endpoints.setHealthy(ep, false)
+		logger.Warn("endpoint failed, failing over", "endpoint", ep.address, "error", failoverErr.err)
+		errs = append(errs, failoverErr.err)
+
+		return false, nil
+	}
+
+	tried := false
+	for _, ep := range c.endpoints.list {
+		if failover {
+			healthy, err := c.healthy(ctx, ep)
+			if !healthy {
+				if err == nil {
+					err = fmt.Errorf("%s failed recently", ep.address)
+				} else {
+					tried = true
+				}
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		tried = true
+		if done, err := try(ep); done {
 			return err
 		}
+	}
 
-		defer res.Body.Close()
-		ok := res.StatusCode >= 200 && res.StatusCode < 300
-		if !ok {
-			resBody, err := io.ReadAll(res.Body)
-			if err != nil || string(resBody) == "" {
-				return fmt.Errorf("%s %s: bad status (%d)", method, url, res.StatusCode)
+	if !tried {
+		ep := c.endpoints.leastRecentlyFailed()
+		logger.Warn("every endpoint failed recently, trying the one that failed least recently", "endpoint", ep.address)
+		if done, err := try(ep); done {
+			if ctx.Err() == nil {
+				// The endpoint answered, so requests may go to it again.
+				c.endpoints.setHealthy(ep, true)
 			}
-			return fmt.Errorf("%s %s: bad status (%d)\n%s", method, url, res.StatusCode, string(resBody))
+			return err
 		}
+	}
+
+	return fmt.Errorf("%w: %w", errNoHealthyEndpoint, errors.Join(errs...))
+}
+
+// doAt sends the request to the endpoint at address. Errors that the next
+// endpoint may not return are wrapped in a failoverError.
+func (c *Client) doAt(ctx context.Context, address, method, path string, rq, resp any) error {
+	logger := hclog.FromContext(ctx)
+	url := fmt.Sprintf("%s/%s", address, strings.TrimPrefix(path, "/"))
+	var body io.Reader
+	if rq != nil {
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(rq); err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		body = &buf
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("constructing http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	logger.Debug("making http request", "method", method, "url", url)
+	res, err := c.doer.Do(req)
+	if isFailover(res, err) {
+		if err != nil {
+			return &failoverError{err}
+		}
+		defer res.Body.Close()
+		return &failoverError{badStatus(method, url, res)}
+	}
+
+	defer res.Body.Close()
+	ok := res.StatusCode >= 200 && res.StatusCode < 300
+	if !ok {
+		return badStatus(method, url, res)
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+
+	return c.decode(path, resBody, resp)
+}
 
-		return json.NewDecoder(res.Body).Decode(resp)
+// badStatus returns the error for an unsuccessful response, including its
+// body when there is one.
+func badStatus(method, url string, res *http.Response) error {
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil || string(resBody) == "" {
+		return fmt.Errorf("%s %s: bad status (%d)", method, url, res.StatusCode)
 	}
-	return f()
+	return fmt.Errorf("%s %s: bad status (%d)\n%s", method, url, res.StatusCode, string(resBody))
 }
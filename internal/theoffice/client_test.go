@@ -5,8 +5,10 @@ package theoffice
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,3 +129,114 @@ func TestClientQuotes_none(t *testing.T) {
 
 	assert.Equal(t, 0, len(resp.Quotes))
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClientTransport(t *testing.T) {
+	var calls int
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		assert.Equal(t, "example.test", r.URL.Host)
+		assert.Equal(t, "/season/2/format/connections", r.URL.Path)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`[{"episode":3,"episode_name":"Office Olympics"}]`)),
+			Request:    r,
+		}, nil
+	})
+
+	c, err := NewClient(&Config{
+		Address:   "https://example.test",
+		Transport: transport,
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetConnections(context.Background(), 2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, len(resp.Connections))
+	assert.Equal(t, 3, resp.Connections[0].Episode)
+	assert.Equal(t, "Office Olympics", resp.Connections[0].EpisodeName)
+}
+
+type fakeDoer struct {
+	requests []*http.Request
+	status   int
+	body     string
+}
+
+func (d *fakeDoer) Do(r *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, r)
+
+	return &http.Response{
+		StatusCode: d.status,
+		Body:       io.NopCloser(strings.NewReader(d.body)),
+		Request:    r,
+	}, nil
+}
+
+func TestClientDoer(t *testing.T) {
+	doer := &fakeDoer{
+		status: http.StatusOK,
+		body:   `[{"season": 3,"episode": 2,"scene": 4,"episode_name": "The Convention","character": "Dwight","quote": "Question."}]`,
+	}
+
+	c, err := NewClient(&Config{
+		Address: "https://example.test",
+		Doer:    doer,
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetQuotes(context.Background(), 3, 2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(doer.requests))
+	assert.Equal(t, "/season/3/episode/2", doer.requests[0].URL.Path)
+	assert.Equal(t, 1, len(resp.Quotes))
+	assert.Equal(t, "Dwight", resp.Quotes[0].Character)
+}
+
+func TestClientDoer_badStatus(t *testing.T) {
+	doer := &fakeDoer{
+		status: http.StatusNotFound,
+		body:   "no such season",
+	}
+
+	c, err := NewClient(&Config{
+		Address: "https://example.test",
+		Doer:    doer,
+	})
+	assert.NoError(t, err)
+
+	_, err = c.GetQuotes(context.Background(), 10, 0)
+	assert.ErrorContains(t, err, "bad status (404)")
+	assert.ErrorContains(t, err, "no such season")
+}
+
+func TestClientUserAgent(t *testing.T) {
+	var userAgents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+
+		_, err := w.Write([]byte(`[]`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{
+		Address:   srv.URL,
+		UserAgent: "terraform-provider-theoffice/test",
+	})
+	assert.NoError(t, err)
+
+	_, err = c.GetQuotes(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"terraform-provider-theoffice/test"}, userAgents)
+}
@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultConnectionWindow is the number of preceding lines in a scene that a
+// line is considered to respond to when deriving connections.
+const DefaultConnectionWindow = 1
+
+const (
+	// WeightingAdjacent counts exchanges: a line links its speaker to each
+	// other character who spoke within the preceding lines of the scene.
+	WeightingAdjacent = "adjacent"
+
+	// WeightingScene counts the scenes in which two characters both speak.
+	WeightingScene = "scene"
+
+	// WeightingMentions counts the lines in which a character says the name
+	// of another character in the episode.
+	WeightingMentions = "mentions"
+)
+
+// Weightings lists the supported link-weighting algorithms.
+var Weightings = []string{WeightingAdjacent, WeightingScene, WeightingMentions}
+
+type ConnectionOptions struct {
+	// Window is the number of preceding lines in the same scene that a line
+	// is considered to respond to. Defaults to DefaultConnectionWindow.
+	Window int
+
+	// Weighting is the algorithm used to compute link values. Defaults to
+	// WeightingAdjacent.
+	Weighting string
+
+	// Directed keeps the direction of each link: from the earlier speaker to
+	// the responder for WeightingAdjacent, and from the speaker to the
+	// character named for WeightingMentions. Scene co-occurrence has no
+	// direction, so WeightingScene links are always undirected.
+	Directed bool
+}
+
+// BuildConnections derives the connections of each episode from its quotes,
+// which must be in scene order. Link values are computed by the configured
+// weighting algorithm. Undirected links have their source and target ordered
+// alphabetically.
+func BuildConnections(quotes []Quote, opts ConnectionOptions) ([]Connection, error) {
+	if opts.Window < 1 {
+		opts.Window = DefaultConnectionWindow
+	}
+	if opts.Weighting == "" {
+		opts.Weighting = WeightingAdjacent
+	}
+
+	var weigh func(*connectionBuilder, []Quote)
+	switch opts.Weighting {
+	case WeightingAdjacent:
+		weigh = func(b *connectionBuilder, episode []Quote) {
+			weighAdjacent(b, episode, opts.Window)
+		}
+	case WeightingScene:
+		opts.Directed = false
+		weigh = weighScene
+	case WeightingMentions:
+		weigh = weighMentions
+	default:
+		return nil, fmt.Errorf("unsupported weighting %q, expected one of %s", opts.Weighting, strings.Join(Weightings, ", "))
+	}
+
+	var connections []Connection
+	for _, episode := range groupEpisodes(quotes) {
+		b := newConnectionBuilder(episode[0], opts.Directed)
+		for _, q := range episode {
+			for _, speaker := range q.SpeakerNames() {
+				b.addNode(speaker)
+			}
+		}
+
+		weigh(b, episode)
+
+		connections = append(connections, b.connection())
+	}
+
+	return connections, nil
+}
+
+func weighAdjacent(b *connectionBuilder, episode []Quote, window int) {
+	for i, q := range episode {
+		seen := map[string]bool{}
+		for j := i - 1; j >= 0 && j >= i-window; j-- {
+			prev := episode[j]
+			if prev.Scene != q.Scene {
+				break
+			}
+			for _, from := range prev.SpeakerNames() {
+				if seen[from] {
+					continue
+				}
+				seen[from] = true
+
+				for _, to := range q.SpeakerNames() {
+					if from != to {
+						b.addLink(from, to)
+					}
+				}
+			}
+		}
+	}
+}
+
+func weighScene(b *connectionBuilder, episode []Quote) {
+	var speakers []string
+	for i, q := range episode {
+		if i > 0 && q.Scene != episode[i-1].Scene {
+			addSceneLinks(b, speakers)
+			speakers = nil
+		}
+		for _, speaker := range q.SpeakerNames() {
+			if !slices.Contains(speakers, speaker) {
+				speakers = append(speakers, speaker)
+			}
+		}
+	}
+	addSceneLinks(b, speakers)
+}
+
+func addSceneLinks(b *connectionBuilder, speakers []string) {
+	for i := range speakers {
+		for j := i + 1; j < len(speakers); j++ {
+			b.addLink(speakers[i], speakers[j])
+		}
+	}
+}
+
+func weighMentions(b *connectionBuilder, episode []Quote) {
+	patterns := map[string]*regexp.Regexp{}
+	for _, n := range b.conn.Nodes {
+		patterns[n.ID] = regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(n.ID) + `\b`)
+	}
+
+	for _, q := range episode {
+		for _, speaker := range q.SpeakerNames() {
+			for _, n := range b.conn.Nodes {
+				if n.ID != speaker && patterns[n.ID].MatchString(q.Quote) {
+					b.addLink(speaker, n.ID)
+				}
+			}
+		}
+	}
+}
+
+// groupEpisodes splits quotes into runs belonging to the same season and
+// episode, in the order the episodes first appear.
+func groupEpisodes(quotes []Quote) [][]Quote {
+	type key struct{ season, episode int }
+
+	var order []key
+	episodes := map[key][]Quote{}
+	for _, q := range quotes {
+		k := key{q.Season, q.Episode}
+		if _, ok := episodes[k]; !ok {
+			order = append(order, k)
+		}
+		episodes[k] = append(episodes[k], q)
+	}
+
+	groups := make([][]Quote, 0, len(order))
+	for _, k := range order {
+		groups = append(groups, episodes[k])
+	}
+
+	return groups
+}
+
+// connectionBuilder accumulates the links and nodes of a single episode,
+// preserving the order in which they first appear.
+type connectionBuilder struct {
+	conn     Connection
+	directed bool
+	links    map[[2]string]int
+	nodes    map[string]bool
+}
+
+func newConnectionBuilder(q Quote, directed bool) *connectionBuilder {
+	return &connectionBuilder{
+		conn: Connection{
+			Episode:     q.Episode,
+			EpisodeName: q.EpisodeName,
+		},
+		directed: directed,
+		links:    map[[2]string]int{},
+		nodes:    map[string]bool{},
+	}
+}
+
+func (b *connectionBuilder) addNode(id string) {
+	if id == "" || b.nodes[id] {
+		return
+	}
+	b.nodes[id] = true
+	b.conn.Nodes = append(b.conn.Nodes, Node{ID: id})
+}
+
+func (b *connectionBuilder) addLink(source, target string) {
+	if !b.directed && source > target {
+		source, target = target, source
+	}
+
+	k := [2]string{source, target}
+	i, ok := b.links[k]
+	if !ok {
+		i = len(b.conn.Links)
+		b.links[k] = i
+		b.conn.Links = append(b.conn.Links, Link{Source: source, Target: target})
+	}
+	b.conn.Links[i].Value++
+}
+
+func (b *connectionBuilder) connection() Connection {
+	return b.conn
+}
+
+// NormalizeLinks returns links without self-loops. Unless directed, each link
+// is also canonicalized so that its source sorts before its target, and links
+// joining the same pair of characters in either direction are merged by
+// summing their values.
+func NormalizeLinks(links []Link, directed bool) []Link {
+	var normalized []Link
+	index := map[[2]string]int{}
+	for _, l := range links {
+		if l.Source == l.Target {
+			continue
+		}
+		if !directed && l.Source > l.Target {
+			l.Source, l.Target = l.Target, l.Source
+		}
+
+		k := [2]string{l.Source, l.Target}
+		if i, ok := index[k]; ok {
+			normalized[i].Value += l.Value
+			continue
+		}
+		index[k] = len(normalized)
+		normalized = append(normalized, l)
+	}
+
+	return normalized
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testQuotes = []Quote{
+	{Season: 1, Episode: 1, Scene: 1, EpisodeName: "Pilot", Character: "Michael", Quote: "All right Jim. Your quarterlies look very good."},
+	{Season: 1, Episode: 1, Scene: 1, EpisodeName: "Pilot", Character: "Jim", Quote: "Oh, I told you. I couldn't close it."},
+	{Season: 1, Episode: 1, Scene: 1, EpisodeName: "Pilot", Character: "Michael", Quote: "So you've come to the master for guidance?"},
+	{Season: 1, Episode: 1, Scene: 2, EpisodeName: "Pilot", Character: "Pam", Quote: "Michael, Todd Packer is on line one."},
+	{Season: 1, Episode: 1, Scene: 2, EpisodeName: "Pilot", Character: "Dwight", Quote: "Whassup!"},
+	{Season: 1, Episode: 1, Scene: 2, EpisodeName: "Pilot", Character: "Michael", Quote: "Whassup!"},
+	{Season: 1, Episode: 2, Scene: 1, EpisodeName: "Diversity Day", Character: "Dwight", Quote: "Question. What is the purpose of this exercise?"},
+	{Season: 1, Episode: 2, Scene: 1, EpisodeName: "Diversity Day", Character: "Michael", Quote: "The purpose is to understand each other."},
+	{Season: 1, Episode: 2, Scene: 2, EpisodeName: "Diversity Day", Character: "Jim", Quote: "I lost the biggest sale of the year today."},
+}
+
+func TestBuildConnections(t *testing.T) {
+	connections, err := BuildConnections(testQuotes, ConnectionOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Connection{
+		{
+			Episode:     1,
+			EpisodeName: "Pilot",
+			Links: []Link{
+				{Source: "Jim", Target: "Michael", Value: 2},
+				{Source: "Dwight", Target: "Pam", Value: 1},
+				{Source: "Dwight", Target: "Michael", Value: 1},
+			},
+			Nodes: []Node{{ID: "Michael"}, {ID: "Jim"}, {ID: "Pam"}, {ID: "Dwight"}},
+		},
+		{
+			Episode:     2,
+			EpisodeName: "Diversity Day",
+			Links: []Link{
+				{Source: "Dwight", Target: "Michael", Value: 1},
+			},
+			Nodes: []Node{{ID: "Dwight"}, {ID: "Michael"}, {ID: "Jim"}},
+		},
+	}, connections)
+}
+
+func TestBuildConnections_window(t *testing.T) {
+	connections, err := BuildConnections(testQuotes[3:6], ConnectionOptions{Window: 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(connections))
+	assert.Equal(t, []Link{
+		{Source: "Dwight", Target: "Pam", Value: 1},
+		{Source: "Dwight", Target: "Michael", Value: 1},
+		{Source: "Michael", Target: "Pam", Value: 1},
+	}, connections[0].Links)
+}
+
+func TestBuildConnections_directed(t *testing.T) {
+	connections, err := BuildConnections(testQuotes[:3], ConnectionOptions{Directed: true})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Link{
+		{Source: "Michael", Target: "Jim", Value: 1},
+		{Source: "Jim", Target: "Michael", Value: 1},
+	}, connections[0].Links)
+}
+
+func TestBuildConnections_scene(t *testing.T) {
+	connections, err := BuildConnections(testQuotes, ConnectionOptions{Weighting: WeightingScene, Directed: true})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(connections))
+	assert.Equal(t, []Link{
+		{Source: "Jim", Target: "Michael", Value: 1},
+		{Source: "Dwight", Target: "Pam", Value: 1},
+		{Source: "Michael", Target: "Pam", Value: 1},
+		{Source: "Dwight", Target: "Michael", Value: 1},
+	}, connections[0].Links)
+	assert.Equal(t, []Link{
+		{Source: "Dwight", Target: "Michael", Value: 1},
+	}, connections[1].Links)
+}
+
+func TestBuildConnections_mentions(t *testing.T) {
+	connections, err := BuildConnections(testQuotes, ConnectionOptions{Weighting: WeightingMentions, Directed: true})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(connections))
+	assert.Equal(t, []Link{
+		{Source: "Michael", Target: "Jim", Value: 1},
+		{Source: "Pam", Target: "Michael", Value: 1},
+	}, connections[0].Links)
+	assert.Empty(t, connections[1].Links)
+}
+
+func TestBuildConnections_unsupportedWeighting(t *testing.T) {
+	_, err := BuildConnections(testQuotes, ConnectionOptions{Weighting: "vibes"})
+	assert.ErrorContains(t, err, `unsupported weighting "vibes"`)
+}
+
+func TestBuildConnections_none(t *testing.T) {
+	connections, err := BuildConnections(nil, ConnectionOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, connections)
+}
+
+func TestNormalizeLinks(t *testing.T) {
+	links := []Link{
+		{Source: "Pam", Target: "Jim", Value: 3},
+		{Source: "Michael", Target: "Michael", Value: 7},
+		{Source: "Jim", Target: "Pam", Value: 2},
+		{Source: "Dwight", Target: "Jim", Value: 1},
+	}
+
+	assert.Equal(t, []Link{
+		{Source: "Jim", Target: "Pam", Value: 5},
+		{Source: "Dwight", Target: "Jim", Value: 1},
+	}, NormalizeLinks(links, false))
+
+	assert.Equal(t, []Link{
+		{Source: "Pam", Target: "Jim", Value: 3},
+		{Source: "Jim", Target: "Pam", Value: 2},
+		{Source: "Dwight", Target: "Jim", Value: 1},
+	}, NormalizeLinks(links, true))
+}
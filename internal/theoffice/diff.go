@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Kinds of change between a base dataset and the dataset compared to it.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// QuoteDiff is a quote that differs between two datasets, identified by its
+// position in the show.
+type QuoteDiff struct {
+	Change string
+
+	// Key, Season, Episode, Scene and Line locate the quote, as in
+	// KeyedQuote.
+	Key                          string
+	Season, Episode, Scene, Line int
+
+	// Base and Compare are the quote in each dataset, and nil when it is
+	// missing from one.
+	Base, Compare *Quote
+}
+
+// DiffQuotes returns the quotes added, removed or changed in compare relative
+// to base, ordered by their position in the show. Both must hold every line
+// of their scenes in the order they occur, as for KeyQuotes.
+func DiffQuotes(base, compare []Quote) []QuoteDiff {
+	diffs := map[string]*QuoteDiff{}
+	for _, q := range KeyQuotes(base) {
+		d := newQuoteDiff(ChangeRemoved, q)
+		d.Base = &q.Quote
+		diffs[q.Key] = d
+	}
+
+	for _, q := range KeyQuotes(compare) {
+		d, ok := diffs[q.Key]
+		if !ok {
+			d = newQuoteDiff(ChangeAdded, q)
+			d.Compare = &q.Quote
+			diffs[q.Key] = d
+			continue
+		}
+
+		if sameQuote(*d.Base, q.Quote) {
+			delete(diffs, q.Key)
+			continue
+		}
+		d.Change = ChangeChanged
+		d.Compare = &q.Quote
+	}
+
+	result := make([]QuoteDiff, 0, len(diffs))
+	for _, d := range diffs {
+		result = append(result, *d)
+	}
+	slices.SortFunc(result, func(a, b QuoteDiff) int {
+		return cmp.Or(
+			cmp.Compare(a.Season, b.Season),
+			cmp.Compare(a.Episode, b.Episode),
+			cmp.Compare(a.Scene, b.Scene),
+			cmp.Compare(a.Line, b.Line),
+		)
+	})
+
+	return result
+}
+
+// sameQuote reports whether a and b hold the same fields of the API's
+// responses. Speakers are parsed from Character, so they aren't compared.
+func sameQuote(a, b Quote) bool {
+	return a.Season == b.Season && a.Episode == b.Episode && a.Scene == b.Scene &&
+		a.EpisodeName == b.EpisodeName && a.Character == b.Character && a.Quote == b.Quote
+}
+
+func newQuoteDiff(change string, q KeyedQuote) *QuoteDiff {
+	return &QuoteDiff{
+		Change:  change,
+		Key:     q.Key,
+		Season:  q.Season,
+		Episode: q.Episode,
+		Scene:   q.Scene,
+		Line:    q.Line,
+	}
+}
+
+// LinkDiff is a link whose value differs between two datasets.
+type LinkDiff struct {
+	Change string
+
+	Season, Episode int
+	Source, Target  string
+
+	// BaseValue and CompareValue are the value of the link in each dataset,
+	// and zero when it is missing from one.
+	BaseValue, CompareValue int
+}
+
+// DiffConnections returns the links added, removed or changed in compare
+// relative to base, which are connections of the given season. Links between
+// the same characters in an episode are summed. Diffs are ordered by episode,
+// source and target.
+func DiffConnections(season int, base, compare []Connection) []LinkDiff {
+	type linkKey struct {
+		episode        int
+		source, target string
+	}
+
+	values := func(connections []Connection) map[linkKey]int {
+		m := map[linkKey]int{}
+		for _, c := range connections {
+			for _, l := range c.Links {
+				m[linkKey{c.Episode, l.Source, l.Target}] += l.Value
+			}
+		}
+		return m
+	}
+	baseValues, compareValues := values(base), values(compare)
+
+	var diffs []LinkDiff
+	for k, v := range baseValues {
+		d := LinkDiff{Season: season, Episode: k.episode, Source: k.source, Target: k.target, BaseValue: v}
+		switch cv, ok := compareValues[k]; {
+		case !ok:
+			d.Change = ChangeRemoved
+		case cv != v:
+			d.Change = ChangeChanged
+			d.CompareValue = cv
+		default:
+			continue
+		}
+		diffs = append(diffs, d)
+	}
+	for k, v := range compareValues {
+		if _, ok := baseValues[k]; !ok {
+			diffs = append(diffs, LinkDiff{
+				Change:       ChangeAdded,
+				Season:       season,
+				Episode:      k.episode,
+				Source:       k.source,
+				Target:       k.target,
+				CompareValue: v,
+			})
+		}
+	}
+
+	slices.SortFunc(diffs, func(a, b LinkDiff) int {
+		return cmp.Or(
+			cmp.Compare(a.Episode, b.Episode),
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Target, b.Target),
+		)
+	})
+
+	return diffs
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffQuotes(t *testing.T) {
+	assert.Empty(t, DiffQuotes(testQuotes, testQuotes))
+
+	compare := append([]Quote(nil), testQuotes[:8]...)
+	compare[1].Quote = "Oh, I told you. I couldn't close it. So..."
+	compare = append(compare, Quote{Season: 1, Episode: 2, Scene: 1, EpisodeName: "Diversity Day", Character: "Jim", Quote: "Yes."})
+
+	diffs := DiffQuotes(testQuotes, compare)
+	assert.Equal(t, []QuoteDiff{
+		{Change: ChangeChanged, Key: "s1e1sc1-2", Season: 1, Episode: 1, Scene: 1, Line: 2, Base: &testQuotes[1], Compare: &compare[1]},
+		{Change: ChangeAdded, Key: "s1e2sc1-3", Season: 1, Episode: 2, Scene: 1, Line: 3, Compare: &compare[8]},
+		{Change: ChangeRemoved, Key: "s1e2sc2-1", Season: 1, Episode: 2, Scene: 2, Line: 1, Base: &testQuotes[8]},
+	}, diffs)
+}
+
+func TestDiffConnections(t *testing.T) {
+	base := []Connection{
+		{Episode: 1, Links: []Link{{Source: "Michael", Target: "Pam", Value: 3}, {Source: "Jim", Target: "Pam", Value: 2}}},
+		{Episode: 2, Links: []Link{{Source: "Dwight", Target: "Michael", Value: 1}}},
+	}
+	assert.Empty(t, DiffConnections(1, base, base))
+
+	compare := []Connection{
+		{Episode: 1, Links: []Link{{Source: "Michael", Target: "Pam", Value: 4}, {Source: "Jim", Target: "Pam", Value: 2}}},
+		{Episode: 2, Links: []Link{{Source: "Dwight", Target: "Jim", Value: 1}}},
+	}
+	assert.Equal(t, []LinkDiff{
+		{Change: ChangeChanged, Season: 1, Episode: 1, Source: "Michael", Target: "Pam", BaseValue: 3, CompareValue: 4},
+		{Change: ChangeAdded, Season: 1, Episode: 2, Source: "Dwight", Target: "Jim", CompareValue: 1},
+		{Change: ChangeRemoved, Season: 1, Episode: 2, Source: "Dwight", Target: "Michael", BaseValue: 1},
+	}, DiffConnections(1, base, compare))
+}
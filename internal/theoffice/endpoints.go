@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	// HealthPath is requested to probe whether an endpoint is healthy.
+	HealthPath = "/season/1/episode/1"
+
+	defaultHealthCheckInterval = 30 * time.Second
+)
+
+// endpoint is an API address and what the Client last learned of its health.
+type endpoint struct {
+	address string
+
+	// checked is when the endpoint last failed a request or was probed, and
+	// is zero when neither has happened yet.
+	checked time.Time
+	healthy bool
+}
+
+// endpoints is the ordered list of addresses a Client sends requests to.
+type endpoints struct {
+	mu       sync.Mutex
+	list     []*endpoint
+	interval time.Duration
+}
+
+func newEndpoints(addresses []string, interval time.Duration) *endpoints {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	e := &endpoints{interval: interval}
+	for _, address := range addresses {
+		e.list = append(e.list, &endpoint{address: address})
+	}
+
+	return e
+}
+
+// needsProbe reports whether ep's health is unknown, or it was unhealthy
+// long enough ago to check again.
+func (e *endpoints) needsProbe(ep *endpoint) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return ep.checked.IsZero() || !ep.healthy && time.Since(ep.checked) >= e.interval
+}
+
+func (e *endpoints) isHealthy(ep *endpoint) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return ep.healthy
+}
+
+func (e *endpoints) setHealthy(ep *endpoint, healthy bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ep.healthy = healthy
+	ep.checked = time.Now()
+}
+
+// leastRecentlyFailed returns the endpoint that last failed the longest ago.
+func (e *endpoints) leastRecentlyFailed() *endpoint {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	oldest := e.list[0]
+	for _, ep := range e.list[1:] {
+		if ep.checked.Before(oldest.checked) {
+			oldest = ep
+		}
+	}
+
+	return oldest
+}
+
+// healthy reports whether requests should be sent to ep, probing it first
+// when needed.
+func (c *Client) healthy(ctx context.Context, ep *endpoint) (bool, error) {
+	if !c.endpoints.needsProbe(ep) {
+		return c.endpoints.isHealthy(ep), nil
+	}
+
+	err := c.probe(ctx, ep.address)
+	c.endpoints.setHealthy(ep, err == nil)
+
+	hclog.FromContext(ctx).Named("theoffice_client").Debug("probed endpoint health", "endpoint", ep.address, "healthy", err == nil)
+
+	return err == nil, err
+}
+
+// probe requests HealthPath from address, failing on connection errors and
+// server errors.
+func (c *Client) probe(ctx context.Context, address string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", address+HealthPath, nil)
+	if err != nil {
+		return fmt.Errorf("constructing http request: %w", err)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	res, err := c.doer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 500 {
+		return fmt.Errorf("GET %s%s: bad status (%d)", address, HealthPath, res.StatusCode)
+	}
+
+	return nil
+}
+
+// failoverError marks an error that the next endpoint may not return.
+type failoverError struct {
+	err error
+}
+
+func (e *failoverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *failoverError) Unwrap() error {
+	return e.err
+}
+
+// isFailover reports whether a response, or the error returned in its
+// place, should be retried against the next endpoint.
+func isFailover(res *http.Response, err error) bool {
+	return err != nil || res.StatusCode >= 500
+}
+
+// failoverRetryPolicy leaves connection errors and server errors to failover
+// rather than retrying them against the same endpoint, and otherwise retries
+// like the default policy, such as when rate limited.
+func failoverRetryPolicy(ctx context.Context, res *http.Response, err error) (bool, error) {
+	if isFailover(res, err) {
+		return false, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, res, err)
+}
+
+// errNoHealthyEndpoint is returned when every endpoint fails.
+var errNoHealthyEndpoint = errors.New("no healthy theOffice API endpoint")
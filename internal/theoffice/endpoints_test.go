@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testEndpoint is an API endpoint that serves a single quote, or fails with
+// status for the paths it is told to.
+type testEndpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	failing  func(path string) bool
+	requests []string
+}
+
+func newTestEndpoint(t *testing.T, character string) *testEndpoint {
+	t.Helper()
+
+	e := &testEndpoint{}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.mu.Lock()
+		e.requests = append(e.requests, r.URL.Path)
+		status, failing := e.status, e.failing
+		e.mu.Unlock()
+
+		if status != 0 && (failing == nil || failing(r.URL.Path)) {
+			w.WriteHeader(status)
+			return
+		}
+
+		_, err := w.Write([]byte(`[{"season": 1,"episode": 1,"scene": 1,"episode_name": "Pilot","character": "` + character + `","quote": "Hello."}]`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(e.Close)
+
+	return e
+}
+
+// fail makes the endpoint respond with status to paths failing returns true
+// for, or to every path when failing is nil. A zero status stops failing.
+func (e *testEndpoint) fail(status int, failing func(path string) bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.status, e.failing = status, failing
+}
+
+func (e *testEndpoint) served() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]string(nil), e.requests...)
+}
+
+func TestClientFailover_unhealthyProbe(t *testing.T) {
+	primary, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	primary.fail(http.StatusServiceUnavailable, nil)
+
+	c, err := NewClient(&Config{
+		Addresses: []string{primary.URL, mirror.URL},
+	})
+	assert.NoError(t, err)
+
+	for range 2 {
+		resp, err := c.GetQuotes(context.Background(), 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "Dwight", resp.Quotes[0].Character)
+	}
+
+	assert.Equal(t, []string{HealthPath}, primary.served())
+	assert.Equal(t, []string{HealthPath, "/season/1/format/quotes", "/season/1/format/quotes"}, mirror.served())
+}
+
+func TestClientFailover_serverError(t *testing.T) {
+	primary, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	primary.fail(http.StatusInternalServerError, func(path string) bool { return path != HealthPath })
+
+	c, err := NewClient(&Config{
+		Addresses: []string{primary.URL, mirror.URL},
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetQuotes(context.Background(), 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Dwight", resp.Quotes[0].Character)
+
+	// Server errors are failed over rather than retried.
+	assert.Equal(t, []string{HealthPath, "/season/2/format/quotes"}, primary.served())
+	assert.Equal(t, []string{HealthPath, "/season/2/format/quotes"}, mirror.served())
+}
+
+func TestClientFailover_connectionError(t *testing.T) {
+	down, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	down.Close()
+
+	c, err := NewClient(&Config{
+		Addresses: []string{down.URL, mirror.URL},
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetConnections(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Connections))
+}
+
+func TestClientFailover_recovery(t *testing.T) {
+	primary, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	primary.fail(http.StatusBadGateway, nil)
+
+	c, err := NewClient(&Config{
+		Addresses:           []string{primary.URL, mirror.URL},
+		HealthCheckInterval: time.Millisecond,
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Dwight", resp.Quotes[0].Character)
+
+	primary.fail(0, nil)
+	time.Sleep(10 * time.Millisecond)
+
+	resp, err = c.GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Michael", resp.Quotes[0].Character)
+}
+
+func TestClientFailover_noHealthyEndpoint(t *testing.T) {
+	primary, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	primary.fail(http.StatusServiceUnavailable, nil)
+	mirror.fail(http.StatusServiceUnavailable, nil)
+
+	c, err := NewClient(&Config{
+		Addresses: []string{primary.URL, mirror.URL},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.GetQuotes(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, errNoHealthyEndpoint)
+	assert.ErrorContains(t, err, "bad status (503)")
+}
+
+func TestClientFailover_everyEndpointFailedRecently(t *testing.T) {
+	primary, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	primary.fail(http.StatusInternalServerError, func(path string) bool { return path != HealthPath })
+	mirror.fail(http.StatusInternalServerError, func(path string) bool { return path != HealthPath })
+
+	c, err := NewClient(&Config{
+		Addresses: []string{primary.URL, mirror.URL},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.GetQuotes(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, errNoHealthyEndpoint)
+
+	// Both endpoints recover well before they would be probed again.
+	primary.fail(0, nil)
+	mirror.fail(0, nil)
+
+	resp, err := c.GetQuotes(context.Background(), 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Michael", resp.Quotes[0].Character)
+
+	resp, err = c.GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Michael", resp.Quotes[0].Character)
+
+	assert.Equal(t, []string{HealthPath, "/season/1/format/quotes", "/season/1/format/quotes", "/season/1/episode/1"}, primary.served())
+	assert.Equal(t, []string{HealthPath, "/season/1/format/quotes"}, mirror.served())
+}
+
+func TestClientFailover_clientErrorsAreReturned(t *testing.T) {
+	primary, mirror := newTestEndpoint(t, "Michael"), newTestEndpoint(t, "Dwight")
+	primary.fail(http.StatusNotFound, func(path string) bool { return path != HealthPath })
+
+	c, err := NewClient(&Config{
+		Addresses: []string{primary.URL, mirror.URL},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.GetQuotes(context.Background(), 10, 0)
+	assert.ErrorContains(t, err, "bad status (404)")
+	assert.Empty(t, mirror.served())
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// EndpointHealth is how an endpoint responded to a health check.
+type EndpointHealth struct {
+	Address string
+
+	// Status is the HTTP status of the response to HealthPath, and is zero
+	// when no response was received.
+	Status int
+
+	// Latency is how long the response to HealthPath took to be read in full.
+	Latency time.Duration
+
+	// Size is the size of the body of the response to HealthPath, in bytes.
+	Size int
+
+	// CatalogueComplete reports whether the endpoint serves every episode of
+	// the season checked.
+	CatalogueComplete bool
+
+	// Err is why the endpoint is unhealthy or its catalogue couldn't be
+	// checked, and is nil otherwise.
+	Err error
+}
+
+// Healthy reports whether the endpoint answered HealthPath successfully.
+func (h EndpointHealth) Healthy() bool {
+	return h.Status >= 200 && h.Status < 300
+}
+
+// Addresses returns the addresses of the endpoints the client sends requests
+// to, in order of preference.
+func (c *Client) Addresses() []string {
+	addresses := make([]string, 0, len(c.endpoints.list))
+	for _, ep := range c.endpoints.list {
+		addresses = append(addresses, ep.address)
+	}
+
+	return addresses
+}
+
+// CheckHealth requests HealthPath from every endpoint, then the connections
+// of season from those that answered, to check that none of its episodes are
+// missing. Unlike other requests, failures are reported rather than returned,
+// and endpoints aren't failed over nor marked unhealthy.
+func (c *Client) CheckHealth(ctx context.Context, season int) []EndpointHealth {
+	results := make([]EndpointHealth, 0, len(c.endpoints.list))
+	for _, ep := range c.endpoints.list {
+		h := c.checkHealth(ctx, ep.address)
+		if h.Healthy() {
+			h.CatalogueComplete, h.Err = c.catalogueComplete(ctx, ep.address, season)
+		}
+		results = append(results, h)
+	}
+
+	return results
+}
+
+func (c *Client) checkHealth(ctx context.Context, address string) EndpointHealth {
+	h := EndpointHealth{Address: address}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", address+HealthPath, nil)
+	if err != nil {
+		h.Err = fmt.Errorf("constructing http request: %w", err)
+		return h
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	start := time.Now()
+	res, err := c.doer.Do(req)
+	if err != nil {
+		h.Latency = time.Since(start)
+		h.Err = err
+		return h
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	h.Latency = time.Since(start)
+	h.Status = res.StatusCode
+	h.Size = len(body)
+	switch {
+	case err != nil:
+		h.Status = 0
+		h.Err = fmt.Errorf("reading response: %w", err)
+	case !h.Healthy():
+		h.Err = fmt.Errorf("GET %s%s: bad status (%d)", address, HealthPath, res.StatusCode)
+	}
+
+	return h
+}
+
+// catalogueComplete reports whether the endpoint at address serves the
+// connections of every episode of season.
+func (c *Client) catalogueComplete(ctx context.Context, address string, season int) (bool, error) {
+	var connections []Connection
+	if err := c.doAt(ctx, address, "GET", fmt.Sprintf("/season/%d/format/connections", season), nil, &connections); err != nil {
+		return false, err
+	}
+
+	served := make(map[int]bool)
+	for _, conn := range connections {
+		if ValidEpisode(season, conn.Episode) {
+			served[conn.Episode] = true
+		}
+	}
+
+	return len(served) == NumEpisodes(season), nil
+}
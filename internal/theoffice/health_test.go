@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCatalogueServer serves episodes of season 1's connections, and a single
+// quote for every other path.
+func newCatalogueServer(t *testing.T, episodes int) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/season/1/format/connections" {
+			_, err := w.Write([]byte(`[{"season": 1,"episode": 1,"scene": 1,"episode_name": "Pilot","character": "Michael","quote": "Hello."}]`))
+			assert.NoError(t, err)
+			return
+		}
+
+		var connections []string
+		for e := 1; e <= episodes; e++ {
+			connections = append(connections, fmt.Sprintf(`{"episode": %d}`, e))
+		}
+		_, err := w.Write([]byte("[" + strings.Join(connections, ",") + "]"))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClientCheckHealth(t *testing.T) {
+	complete, partial := newCatalogueServer(t, NumEpisodes(1)), newCatalogueServer(t, 2)
+	failing := newTestEndpoint(t, "Michael")
+	failing.fail(http.StatusServiceUnavailable, nil)
+
+	c, err := NewClient(&Config{
+		Addresses: []string{failing.URL, complete.URL, partial.URL},
+		Doer:      http.DefaultClient,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{failing.URL, complete.URL, partial.URL}, c.Addresses())
+
+	results := c.CheckHealth(context.Background(), 1)
+	assert.Equal(t, 3, len(results))
+
+	assert.Equal(t, failing.URL, results[0].Address)
+	assert.False(t, results[0].Healthy())
+	assert.Equal(t, http.StatusServiceUnavailable, results[0].Status)
+	assert.False(t, results[0].CatalogueComplete)
+	assert.ErrorContains(t, results[0].Err, "bad status (503)")
+	// The catalogue of an unhealthy endpoint isn't checked.
+	assert.Equal(t, []string{HealthPath}, failing.served())
+
+	assert.True(t, results[1].Healthy())
+	assert.Equal(t, http.StatusOK, results[1].Status)
+	assert.Positive(t, results[1].Size)
+	assert.Positive(t, results[1].Latency)
+	assert.True(t, results[1].CatalogueComplete)
+	assert.NoError(t, results[1].Err)
+
+	assert.True(t, results[2].Healthy())
+	assert.False(t, results[2].CatalogueComplete)
+	assert.NoError(t, results[2].Err)
+}
+
+func TestClientCheckHealth_connectionError(t *testing.T) {
+	down := newTestEndpoint(t, "Michael")
+	down.Close()
+
+	c, err := NewClient(&Config{
+		Address: down.URL,
+		Doer:    http.DefaultClient,
+	})
+	assert.NoError(t, err)
+
+	results := c.CheckHealth(context.Background(), 1)
+	assert.Equal(t, 1, len(results))
+	assert.False(t, results[0].Healthy())
+	assert.Zero(t, results[0].Status)
+	assert.Error(t, results[0].Err)
+}
@@ -0,0 +1,345 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultSearchLimit is the number of results returned by a search.
+	DefaultSearchLimit = 10
+
+	// MaxFuzziness is the largest edit distance supported by fuzzy terms.
+	MaxFuzziness = 2
+
+	// bm25K1 controls how quickly repeated terms saturate a quote's score.
+	bm25K1 = 1.2
+
+	// bm25B controls how much a quote's length normalizes its score.
+	bm25B = 0.75
+)
+
+// QuoteIndex is an inverted index of quotes, searched with BM25 ranking or by
+// TF-IDF similarity.
+type QuoteIndex struct {
+	quotes []Quote
+
+	// postings maps each term to the positions it occurs at in each quote.
+	postings map[string]map[int][]int
+
+	// terms are the indexed terms, sorted for prefix lookups.
+	terms []string
+
+	lengths   []int
+	avgLength float64
+
+	// norms are the lengths of the quotes' TF-IDF vectors, computed on the
+	// first similarity search.
+	normsOnce sync.Once
+	norms     []float64
+}
+
+// NewQuoteIndex indexes the words of quotes, as split by Tokenize.
+func NewQuoteIndex(quotes []Quote) *QuoteIndex {
+	idx := &QuoteIndex{
+		quotes:   quotes,
+		postings: map[string]map[int][]int{},
+		lengths:  make([]int, len(quotes)),
+	}
+
+	var total int
+	for doc, q := range quotes {
+		tokens := Tokenize(q.Quote)
+		for pos, t := range tokens {
+			if idx.postings[t] == nil {
+				idx.postings[t] = map[int][]int{}
+			}
+			idx.postings[t][doc] = append(idx.postings[t][doc], pos)
+		}
+		idx.lengths[doc] = len(tokens)
+		total += len(tokens)
+	}
+
+	for t := range idx.postings {
+		idx.terms = append(idx.terms, t)
+	}
+	sort.Strings(idx.terms)
+
+	if len(quotes) > 0 {
+		idx.avgLength = float64(total) / float64(len(quotes))
+	}
+
+	return idx
+}
+
+// Len returns the number of indexed quotes.
+func (idx *QuoteIndex) Len() int {
+	return len(idx.quotes)
+}
+
+type SearchOptions struct {
+	// Limit is the maximum number of results. Defaults to DefaultSearchLimit.
+	Limit int
+
+	// MinScore drops results scoring below it.
+	MinScore float64
+
+	// Fuzziness is the edit distance within which plain query terms match
+	// indexed terms. Zero only matches exact terms.
+	Fuzziness int
+
+	// Exclude, when set, drops the quotes it returns true for before Limit
+	// applies.
+	Exclude func(Quote) bool
+}
+
+type SearchResult struct {
+	Quote Quote
+
+	// Score is the BM25 relevance of the quote, rounded to four decimal
+	// places.
+	Score float64
+}
+
+// Search returns the quotes matching any clause of query, ordered by
+// descending score and then by their order in the index. A query is made of
+// whitespace separated clauses:
+//
+//   - a plain word matches the term exactly, or within SearchOptions.Fuzziness
+//     edits;
+//   - a word ending in "*" matches every term with that prefix;
+//   - a word ending in "~" matches terms within one edit, or within N edits
+//     with "~N";
+//   - words in double quotes match the exact phrase.
+func (idx *QuoteIndex) Search(query string, opts SearchOptions) []SearchResult {
+	if opts.Limit < 1 {
+		opts.Limit = DefaultSearchLimit
+	}
+
+	scores := map[int]float64{}
+	for _, c := range parseQuery(query, opts.Fuzziness) {
+		for doc, score := range idx.scoreClause(c) {
+			scores[doc] += score
+		}
+	}
+
+	var results []SearchResult
+	docs := make([]int, 0, len(scores))
+	for doc := range scores {
+		if opts.Exclude != nil && opts.Exclude(idx.quotes[doc]) {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if scores[docs[i]] != scores[docs[j]] {
+			return scores[docs[i]] > scores[docs[j]]
+		}
+		return docs[i] < docs[j]
+	})
+
+	for _, doc := range docs {
+		score := math.Round(scores[doc]*1e4) / 1e4
+		if score < opts.MinScore || len(results) == opts.Limit {
+			break
+		}
+		results = append(results, SearchResult{Quote: idx.quotes[doc], Score: score})
+	}
+
+	return results
+}
+
+// queryClause is a single term, prefix, fuzzy term or phrase of a query.
+type queryClause struct {
+	// terms holds a single term, or the words of a phrase.
+	terms     []string
+	prefix    bool
+	fuzziness int
+}
+
+func parseQuery(query string, fuzziness int) []queryClause {
+	var clauses []queryClause
+
+	for i, part := range strings.Split(query, `"`) {
+		// Odd parts are between double quotes.
+		if i%2 == 1 {
+			if terms := Tokenize(part); len(terms) > 0 {
+				clauses = append(clauses, queryClause{terms: terms})
+			}
+			continue
+		}
+
+		for _, word := range strings.Fields(part) {
+			c := queryClause{fuzziness: fuzziness}
+			switch {
+			case strings.HasSuffix(word, "*"):
+				word = strings.TrimSuffix(word, "*")
+				c.prefix = true
+				c.fuzziness = 0
+			case strings.Contains(word, "~"):
+				var distance string
+				word, distance, _ = strings.Cut(word, "~")
+				c.fuzziness = 1
+				if n, err := strconv.Atoi(distance); err == nil {
+					c.fuzziness = n
+				}
+			}
+			c.fuzziness = min(max(c.fuzziness, 0), MaxFuzziness)
+
+			c.terms = Tokenize(word)
+			switch {
+			case len(c.terms) == 0:
+				continue
+			case len(c.terms) > 1:
+				// Words such as "dunder-mifflin" are searched as phrases.
+				c = queryClause{terms: c.terms}
+			}
+			clauses = append(clauses, c)
+		}
+	}
+
+	return clauses
+}
+
+func (idx *QuoteIndex) scoreClause(c queryClause) map[int]float64 {
+	if len(c.terms) > 1 {
+		return idx.scorePhrase(c.terms)
+	}
+
+	// A clause expanding to several terms scores each quote by its best
+	// matching term, so that a quote isn't rewarded for near-duplicates.
+	scores := map[int]float64{}
+	for term, weight := range idx.expand(c) {
+		for doc, positions := range idx.postings[term] {
+			score := weight * idx.bm25(term, doc, len(positions))
+			scores[doc] = max(scores[doc], score)
+		}
+	}
+
+	return scores
+}
+
+// expand returns the indexed terms matched by a single-term clause, weighted
+// by how closely they match.
+func (idx *QuoteIndex) expand(c queryClause) map[string]float64 {
+	term := c.terms[0]
+	matches := map[string]float64{}
+
+	switch {
+	case c.prefix:
+		for i := sort.SearchStrings(idx.terms, term); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], term); i++ {
+			matches[idx.terms[i]] = 1
+		}
+	case c.fuzziness > 0:
+		for _, t := range idx.terms {
+			if d := levenshtein(term, t, c.fuzziness); d <= c.fuzziness {
+				matches[t] = 1 / float64(1+d)
+			}
+		}
+	case idx.postings[term] != nil:
+		matches[term] = 1
+	}
+
+	return matches
+}
+
+// scorePhrase scores the quotes containing terms consecutively, counting the
+// occurrences of the whole phrase as the frequency of each of its terms.
+func (idx *QuoteIndex) scorePhrase(terms []string) map[int]float64 {
+	scores := map[int]float64{}
+
+	for doc, positions := range idx.postings[terms[0]] {
+		var freq int
+		for _, start := range positions {
+			if idx.phraseAt(terms, doc, start) {
+				freq++
+			}
+		}
+		if freq == 0 {
+			continue
+		}
+
+		for _, t := range terms {
+			scores[doc] += idx.bm25(t, doc, freq)
+		}
+	}
+
+	return scores
+}
+
+func (idx *QuoteIndex) phraseAt(terms []string, doc, start int) bool {
+	for i, t := range terms[1:] {
+		found := false
+		for _, pos := range idx.postings[t][doc] {
+			if pos == start+i+1 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bm25 scores a term occurring freq times in the quote doc.
+func (idx *QuoteIndex) bm25(term string, doc, freq int) float64 {
+	n := float64(len(idx.quotes))
+	df := float64(len(idx.postings[term]))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+	tf := float64(freq)
+	norm := 1 - bm25B + bm25B*float64(idx.lengths[doc])/idx.avgLength
+
+	return idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+}
+
+// levenshtein returns the edit distance between a and b, or limit+1 when it
+// exceeds limit.
+func levenshtein(a, b string, limit int) int {
+	if abs(utf8.RuneCountInString(a)-utf8.RuneCountInString(b)) > limit {
+		return limit + 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
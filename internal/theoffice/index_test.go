@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchQuotes(results []SearchResult) []string {
+	var quotes []string
+	for _, r := range results {
+		quotes = append(quotes, r.Quote.Quote)
+	}
+	return quotes
+}
+
+func TestQuoteIndex_Search(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+	assert.Equal(t, len(testQuotes), idx.Len())
+
+	results := idx.Search("purpose", SearchOptions{})
+	assert.Equal(t, []string{
+		"The purpose is to understand each other.",
+		"Question. What is the purpose of this exercise?",
+	}, searchQuotes(results))
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	assert.Empty(t, idx.Search("beets", SearchOptions{}))
+	assert.Empty(t, idx.Search("", SearchOptions{}))
+}
+
+func TestQuoteIndex_Search_rarerTermsRankHigher(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	results := idx.Search("whassup question", SearchOptions{})
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, "Question. What is the purpose of this exercise?", results[2].Quote.Quote)
+}
+
+func TestQuoteIndex_Search_prefix(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	assert.Equal(t, []string{"So you've come to the master for guidance?"}, searchQuotes(idx.Search("guid*", SearchOptions{})))
+	assert.Empty(t, idx.Search("guid", SearchOptions{}))
+}
+
+func TestQuoteIndex_Search_fuzzy(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	assert.Equal(t, []string{"I lost the biggest sale of the year today."}, searchQuotes(idx.Search("biggset~2", SearchOptions{})))
+	assert.Empty(t, idx.Search("biggset~1", SearchOptions{}))
+	assert.Equal(t, []string{"All right Jim. Your quarterlies look very good."}, searchQuotes(idx.Search("quartelies", SearchOptions{Fuzziness: 1})))
+
+	exact := idx.Search("today", SearchOptions{})
+	fuzzy := idx.Search("todayy~", SearchOptions{})
+	assert.Equal(t, searchQuotes(exact), searchQuotes(fuzzy))
+	assert.Less(t, fuzzy[0].Score, exact[0].Score)
+}
+
+func TestQuoteIndex_Search_phrase(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	assert.Equal(t, []string{"The purpose is to understand each other."}, searchQuotes(idx.Search(`"purpose is"`, SearchOptions{})))
+	assert.Empty(t, idx.Search(`"is purpose"`, SearchOptions{}))
+}
+
+func TestQuoteIndex_Search_limitAndMinScore(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	all := idx.Search("whassup michael", SearchOptions{})
+	assert.Equal(t, 3, len(all))
+
+	assert.Equal(t, searchQuotes(all[:2]), searchQuotes(idx.Search("whassup michael", SearchOptions{Limit: 2})))
+	assert.Equal(t, searchQuotes(all[:2]), searchQuotes(idx.Search("whassup michael", SearchOptions{MinScore: all[1].Score})))
+}
+
+func TestQuoteIndex_Search_exclude(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	exclude := func(q Quote) bool { return q.Character == "Michael" }
+	var expected []string
+	for _, r := range idx.Search("whassup michael", SearchOptions{}) {
+		if !exclude(r.Quote) {
+			expected = append(expected, r.Quote.Quote)
+		}
+	}
+	assert.NotEmpty(t, expected)
+
+	assert.Equal(t, expected[:1], searchQuotes(idx.Search("whassup michael", SearchOptions{Limit: 1, Exclude: exclude})))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("beets", "beets", 2))
+	assert.Equal(t, 1, levenshtein("beets", "bets", 2))
+	assert.Equal(t, 1, levenshtein("beets", "beats", 2))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting", 3))
+
+	// Distances beyond the limit are reported as limit+1.
+	assert.Equal(t, 3, levenshtein("kitten", "sitting", 2))
+	assert.Equal(t, 3, levenshtein("bears", "battlestar", 2))
+}
@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultPhraseLimit is the number of phrases reported per n-gram size.
+	DefaultPhraseLimit = 10
+
+	// DefaultCatchphraseEpisodes is the number of distinct episodes a
+	// character must repeat a phrase in for it to count as a catchphrase.
+	DefaultCatchphraseEpisodes = 2
+
+	// maxCatchphraseWords is the longest phrase considered a catchphrase.
+	maxCatchphraseWords = 5
+)
+
+// stopwords are common English words that carry little meaning on their own.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`
+		a about above after again against all am an and any are aren't as at
+		be because been before being below between both but by
+		can can't cannot could couldn't did didn't do does doesn't doing don't down during
+		each few for from further had hadn't has hasn't have haven't having he he'd he'll he's
+		her here here's hers herself him himself his how how's i i'd i'll i'm i've if in into
+		is isn't it it's its itself just let's me more most mustn't my myself no nor not now
+		of off on once only or other ought our ours ourselves out over own same shan't she
+		she'd she'll she's should shouldn't so some such than that that's the their theirs
+		them themselves then there there's these they they'd they'll they're they've this
+		those through to too under until up very was wasn't we we'd we'll we're we've were
+		weren't what what's when when's where where's which while who who's whom why why's
+		will with won't would wouldn't you you'd you'll you're you've your yours yourself
+		yourselves oh okay ok yeah yes uh um hey well gonna gotta`) {
+		stopwords[w] = true
+	}
+}
+
+// IsStopword reports whether the lowercase token w is a stopword.
+func IsStopword(w string) bool {
+	return stopwords[w]
+}
+
+// Tokenize splits s into lowercase words. Apostrophes are kept within words,
+// so "that's" is a single token, and all other punctuation is dropped.
+func Tokenize(s string) []string {
+	s = strings.NewReplacer("’", "'", "‘", "'").Replace(strings.ToLower(s))
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	tokens := words[:0]
+	for _, w := range words {
+		if w = strings.Trim(w, "'"); w != "" {
+			tokens = append(tokens, w)
+		}
+	}
+
+	return tokens
+}
+
+// NGrams returns the space-joined runs of n consecutive tokens.
+func NGrams(tokens []string, n int) []string {
+	if n < 1 || len(tokens) < n {
+		return nil
+	}
+
+	grams := make([]string, 0, len(tokens)-n+1)
+	for i := 0; i+n <= len(tokens); i++ {
+		grams = append(grams, strings.Join(tokens[i:i+n], " "))
+	}
+
+	return grams
+}
+
+type PhraseCount struct {
+	Phrase string
+	Count  int
+}
+
+// PhraseStats are the most frequent phrases of each length.
+type PhraseStats struct {
+	Unigrams []PhraseCount
+	Bigrams  []PhraseCount
+	Trigrams []PhraseCount
+}
+
+type CharacterPhraseStats struct {
+	Character string
+	PhraseStats
+}
+
+type PhraseOptions struct {
+	// RemoveStopwords drops stopword unigrams, and bigrams and trigrams
+	// that start or end with a stopword.
+	RemoveStopwords bool
+
+	// Limit is the number of phrases reported per n-gram size. Defaults to
+	// DefaultPhraseLimit.
+	Limit int
+}
+
+// ComputePhraseStats returns the most frequent phrases across all quotes and
+// for each character, ordered by name.
+func ComputePhraseStats(quotes []Quote, opts PhraseOptions) (PhraseStats, []CharacterPhraseStats) {
+	if opts.Limit < 1 {
+		opts.Limit = DefaultPhraseLimit
+	}
+
+	overall := newPhraseCounter()
+	characters := map[string]*phraseCounter{}
+	for _, q := range quotes {
+		tokens := Tokenize(q.Quote)
+
+		var counters []*phraseCounter
+		for _, speaker := range q.SpeakerNames() {
+			c, ok := characters[speaker]
+			if !ok {
+				c = newPhraseCounter()
+				characters[speaker] = c
+			}
+			counters = append(counters, c)
+		}
+
+		for n := 1; n <= 3; n++ {
+			for _, gram := range NGrams(tokens, n) {
+				if opts.RemoveStopwords && hasStopwordEdge(gram) {
+					continue
+				}
+				overall.add(n, gram)
+				for _, c := range counters {
+					c.add(n, gram)
+				}
+			}
+		}
+	}
+
+	perCharacter := make([]CharacterPhraseStats, 0, len(characters))
+	for name, c := range characters {
+		perCharacter = append(perCharacter, CharacterPhraseStats{
+			Character:   name,
+			PhraseStats: c.stats(opts.Limit),
+		})
+	}
+	sort.Slice(perCharacter, func(i, j int) bool {
+		return perCharacter[i].Character < perCharacter[j].Character
+	})
+
+	return overall.stats(opts.Limit), perCharacter
+}
+
+func hasStopwordEdge(gram string) bool {
+	first, last := gram, gram
+	if i := strings.IndexByte(gram, ' '); i >= 0 {
+		first = gram[:i]
+		last = gram[strings.LastIndexByte(gram, ' ')+1:]
+	}
+
+	return stopwords[first] || stopwords[last]
+}
+
+// phraseCounter counts the occurrences of unigrams, bigrams and trigrams.
+type phraseCounter struct {
+	counts [3]map[string]int
+}
+
+func newPhraseCounter() *phraseCounter {
+	return &phraseCounter{
+		counts: [3]map[string]int{{}, {}, {}},
+	}
+}
+
+func (c *phraseCounter) add(n int, gram string) {
+	c.counts[n-1][gram]++
+}
+
+func (c *phraseCounter) stats(limit int) PhraseStats {
+	return PhraseStats{
+		Unigrams: topPhrases(c.counts[0], limit),
+		Bigrams:  topPhrases(c.counts[1], limit),
+		Trigrams: topPhrases(c.counts[2], limit),
+	}
+}
+
+// topPhrases returns the limit most frequent phrases in counts, ordered by
+// count and then alphabetically.
+func topPhrases(counts map[string]int, limit int) []PhraseCount {
+	phrases := make([]PhraseCount, 0, len(counts))
+	for phrase, count := range counts {
+		phrases = append(phrases, PhraseCount{Phrase: phrase, Count: count})
+	}
+
+	sort.Slice(phrases, func(i, j int) bool {
+		if phrases[i].Count != phrases[j].Count {
+			return phrases[i].Count > phrases[j].Count
+		}
+		return phrases[i].Phrase < phrases[j].Phrase
+	})
+
+	if len(phrases) > limit {
+		phrases = phrases[:limit]
+	}
+
+	return phrases
+}
+
+// Catchphrase is a phrase a character repeats across episodes.
+type Catchphrase struct {
+	Character string
+	Phrase    string
+
+	// Episodes is the number of distinct episodes the phrase is said in.
+	Episodes int
+
+	// Count is the number of lines the phrase is said in.
+	Count int
+}
+
+// FindCatchphrases returns the phrases of up to five words that a character
+// says in at least minEpisodes distinct episodes. A single-word line counts
+// as a phrase, but otherwise phrases span at least two words. Phrases of
+// several words contained in a longer catchphrase of the same character and
+// frequency are omitted. Results are ordered by episode count, character and
+// phrase.
+func FindCatchphrases(quotes []Quote, minEpisodes int) []Catchphrase {
+	if minEpisodes < 1 {
+		minEpisodes = DefaultCatchphraseEpisodes
+	}
+
+	type key struct{ character, phrase string }
+	type episodeKey struct{ season, episode int }
+
+	episodes := map[key]map[episodeKey]bool{}
+	counts := map[key]int{}
+	for _, q := range quotes {
+		speakers := q.SpeakerNames()
+		if len(speakers) == 0 {
+			continue
+		}
+
+		tokens := Tokenize(q.Quote)
+		seen := map[string]bool{}
+		for n := 2; n <= maxCatchphraseWords; n++ {
+			for _, gram := range NGrams(tokens, n) {
+				seen[gram] = true
+			}
+		}
+		if len(tokens) == 1 {
+			seen[tokens[0]] = true
+		}
+
+		for phrase := range seen {
+			for _, speaker := range speakers {
+				k := key{speaker, phrase}
+				if episodes[k] == nil {
+					episodes[k] = map[episodeKey]bool{}
+				}
+				episodes[k][episodeKey{q.Season, q.Episode}] = true
+				counts[k]++
+			}
+		}
+	}
+
+	var candidates []Catchphrase
+	for k, eps := range episodes {
+		if len(eps) >= minEpisodes {
+			candidates = append(candidates, Catchphrase{
+				Character: k.character,
+				Phrase:    k.phrase,
+				Episodes:  len(eps),
+				Count:     counts[k],
+			})
+		}
+	}
+
+	subsumed := subsumedCatchphrases(candidates)
+	var catchphrases []Catchphrase
+	for _, c := range candidates {
+		if !subsumed[c.Character+"\x00"+c.Phrase] {
+			catchphrases = append(catchphrases, c)
+		}
+	}
+
+	sort.Slice(catchphrases, func(i, j int) bool {
+		a, b := catchphrases[i], catchphrases[j]
+		if a.Episodes != b.Episodes {
+			return a.Episodes > b.Episodes
+		}
+		if a.Character != b.Character {
+			return a.Character < b.Character
+		}
+		return a.Phrase < b.Phrase
+	})
+
+	return catchphrases
+}
+
+// subsumedCatchphrases returns the candidates of several words that are part
+// of a longer candidate phrase said by the same character just as often,
+// keyed by character and phrase joined by a NUL byte. A phrase said as often
+// as a longer one is also said as often as the phrase one word longer within
+// it, so only the phrases one word shorter than each candidate are checked.
+// Single-word lines are never subsumed, as longer phrases are said in other
+// lines.
+func subsumedCatchphrases(candidates []Catchphrase) map[string]bool {
+	index := make(map[string]Catchphrase, len(candidates))
+	for _, c := range candidates {
+		index[c.Character+"\x00"+c.Phrase] = c
+	}
+
+	subsumed := map[string]bool{}
+	for _, o := range candidates {
+		words := strings.Split(o.Phrase, " ")
+		if len(words) < 3 {
+			continue
+		}
+
+		for _, shorter := range [][]string{words[1:], words[:len(words)-1]} {
+			k := o.Character + "\x00" + strings.Join(shorter, " ")
+			if c, ok := index[k]; ok && c.Episodes == o.Episodes && c.Count == o.Count {
+				subsumed[k] = true
+			}
+		}
+	}
+
+	return subsumed
+}
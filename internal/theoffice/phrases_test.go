@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"that's", "what", "she", "said"}, Tokenize("That's what she said!"))
+	assert.Equal(t, []string{"i", "couldn't", "close", "it", "so"}, Tokenize("I couldn’t close it. So..."))
+	assert.Equal(t, []string{"god", "we've", "never", "worked", "here", "before"}, Tokenize("'God we've never worked here before.'"))
+	assert.Equal(t, []string{"12", "years"}, Tokenize("12 years -- "))
+	assert.Empty(t, Tokenize("..."))
+}
+
+func TestNGrams(t *testing.T) {
+	tokens := []string{"bears", "beets", "battlestar", "galactica"}
+
+	assert.Equal(t, tokens, NGrams(tokens, 1))
+	assert.Equal(t, []string{"bears beets", "beets battlestar", "battlestar galactica"}, NGrams(tokens, 2))
+	assert.Equal(t, []string{"bears beets battlestar galactica"}, NGrams(tokens, 4))
+	assert.Empty(t, NGrams(tokens, 5))
+	assert.Empty(t, NGrams(tokens, 0))
+}
+
+var testPhraseQuotes = []Quote{
+	{Character: "Dwight", Quote: "Bears. Beets. Battlestar Galactica."},
+	{Character: "Jim", Quote: "Bears eat beets. Bears. Beets. Battlestar Galactica."},
+	{Character: "Dwight", Quote: "That is the worst impression of me."},
+}
+
+func TestComputePhraseStats(t *testing.T) {
+	overall, perCharacter := ComputePhraseStats(testPhraseQuotes, PhraseOptions{Limit: 2})
+
+	assert.Equal(t, []PhraseCount{{Phrase: "bears", Count: 3}, {Phrase: "beets", Count: 3}}, overall.Unigrams)
+	assert.Equal(t, []PhraseCount{{Phrase: "battlestar galactica", Count: 2}, {Phrase: "bears beets", Count: 2}}, overall.Bigrams)
+	assert.Equal(t, []PhraseCount{{Phrase: "bears beets battlestar", Count: 2}, {Phrase: "beets battlestar galactica", Count: 2}}, overall.Trigrams)
+
+	assert.Equal(t, 2, len(perCharacter))
+	assert.Equal(t, "Dwight", perCharacter[0].Character)
+	assert.Equal(t, []PhraseCount{{Phrase: "battlestar", Count: 1}, {Phrase: "bears", Count: 1}}, perCharacter[0].Unigrams)
+	assert.Equal(t, "Jim", perCharacter[1].Character)
+	assert.Equal(t, []PhraseCount{{Phrase: "bears", Count: 2}, {Phrase: "beets", Count: 2}}, perCharacter[1].Unigrams)
+}
+
+func TestComputePhraseStats_removeStopwords(t *testing.T) {
+	overall, perCharacter := ComputePhraseStats(testPhraseQuotes[2:], PhraseOptions{RemoveStopwords: true})
+
+	assert.Equal(t, []PhraseCount{{Phrase: "impression", Count: 1}, {Phrase: "worst", Count: 1}}, overall.Unigrams)
+	assert.Equal(t, []PhraseCount{{Phrase: "worst impression", Count: 1}}, overall.Bigrams)
+	assert.Empty(t, overall.Trigrams)
+
+	assert.Equal(t, 1, len(perCharacter))
+	assert.Equal(t, overall, perCharacter[0].PhraseStats)
+}
+
+func TestFindCatchphrases(t *testing.T) {
+	quotes := []Quote{
+		{Season: 1, Episode: 1, Character: "Michael", Quote: "That's what she said."},
+		{Season: 1, Episode: 2, Character: "Michael", Quote: "No. That's what she said!"},
+		{Season: 1, Episode: 2, Character: "Michael", Quote: "That's what she said."},
+		{Season: 1, Episode: 2, Character: "Dwight", Quote: "Question."},
+		{Season: 2, Episode: 1, Character: "Dwight", Quote: "Question. Can I be a ninja?"},
+		{Season: 2, Episode: 1, Character: "Dwight", Quote: "Question."},
+		{Season: 2, Episode: 1, Character: "Jim", Quote: "That's what she said."},
+	}
+
+	assert.Equal(t, []Catchphrase{
+		{Character: "Dwight", Phrase: "question", Episodes: 2, Count: 2},
+		{Character: "Michael", Phrase: "that's what she said", Episodes: 2, Count: 3},
+	}, FindCatchphrases(quotes, 0))
+
+	assert.Empty(t, FindCatchphrases(quotes, 3))
+}
+
+// showQuotes returns n generated quotes spread over the show's episodes,
+// whose words follow a Zipf distribution so that characters repeat phrases
+// across episodes as often as in the full show.
+func showQuotes(n int) []Quote {
+	r := rand.New(rand.NewPCG(1, 2))
+	words := rand.NewZipf(r, 1.1, 1, 5000)
+
+	var episodes [][2]int
+	for season := 1; season <= NumSeasons; season++ {
+		for episode := 1; episode <= NumEpisodes(season); episode++ {
+			episodes = append(episodes, [2]int{season, episode})
+		}
+	}
+
+	quotes := make([]Quote, n)
+	for i := range quotes {
+		ep := episodes[i*len(episodes)/n]
+		line := make([]string, 1+r.IntN(12))
+		for j := range line {
+			line[j] = fmt.Sprintf("w%d", words.Uint64())
+		}
+		quotes[i] = Quote{
+			Season:    ep[0],
+			Episode:   ep[1],
+			Scene:     1 + r.IntN(40),
+			Character: fmt.Sprintf("Character %d", r.IntN(30)),
+			Quote:     strings.Join(line, " "),
+		}
+	}
+
+	return quotes
+}
+
+func BenchmarkFindCatchphrases(b *testing.B) {
+	// About as many lines as the full show.
+	quotes := showQuotes(60000)
+	b.ResetTimer()
+
+	for range b.N {
+		FindCatchphrases(quotes, 0)
+	}
+}
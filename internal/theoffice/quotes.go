@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+const (
+	// QuoteOrderScene orders quotes as they occur: by season, episode and
+	// scene, keeping the order of lines within a scene.
+	QuoteOrderScene = "scene"
+
+	// QuoteOrderCharacter orders quotes alphabetically by character.
+	QuoteOrderCharacter = "character"
+
+	// QuoteOrderLength orders quotes by descending word count.
+	QuoteOrderLength = "length"
+)
+
+// QuoteOrders lists the supported quote orders.
+var QuoteOrders = []string{QuoteOrderScene, QuoteOrderCharacter, QuoteOrderLength}
+
+// QuoteOrderFunc returns the comparison function of the given order,
+// defaulting to QuoteOrderScene, for use with slices.SortStableFunc. Quotes
+// that compare equal should keep their relative order, so that the result is
+// deterministic for a given input.
+func QuoteOrderFunc(order string) (func(a, b Quote) int, error) {
+	switch order {
+	case "", QuoteOrderScene:
+		return func(a, b Quote) int {
+			return cmp.Or(
+				cmp.Compare(a.Season, b.Season),
+				cmp.Compare(a.Episode, b.Episode),
+				cmp.Compare(a.Scene, b.Scene),
+			)
+		}, nil
+	case QuoteOrderCharacter:
+		return func(a, b Quote) int {
+			return strings.Compare(a.Character, b.Character)
+		}, nil
+	case QuoteOrderLength:
+		return func(a, b Quote) int {
+			return cmp.Compare(WordCount(b.Quote), WordCount(a.Quote))
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported quote order %q, expected one of %s", order, strings.Join(QuoteOrders, ", "))
+	}
+}
+
+// Page returns at most limit items starting at offset. A limit of zero or
+// less returns every item from offset on.
+func Page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[max(offset, 0):]
+
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
+
+// KeyedQuote is a quote with a key identifying it by its position in the
+// show, such as "s1e1sc3-2" for the second line of scene 3 of the pilot.
+// Keys don't depend on which other quotes are read, so they are stable
+// across filters and orders.
+type KeyedQuote struct {
+	Key string
+
+	// Line is the position of the quote in its scene, from 1.
+	Line int
+
+	Quote
+}
+
+// KeyQuotes keys quotes, which must hold every line of their scenes in the
+// order they occur.
+func KeyQuotes(quotes []Quote) []KeyedQuote {
+	type sceneKey struct{ season, episode, scene int }
+
+	lines := map[sceneKey]int{}
+	keyed := make([]KeyedQuote, 0, len(quotes))
+	for _, q := range quotes {
+		k := sceneKey{q.Season, q.Episode, q.Scene}
+		lines[k]++
+		keyed = append(keyed, KeyedQuote{
+			Key:   fmt.Sprintf("s%de%dsc%d-%d", q.Season, q.Episode, q.Scene, lines[k]),
+			Line:  lines[k],
+			Quote: q,
+		})
+	}
+
+	return keyed
+}
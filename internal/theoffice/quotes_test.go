@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sortQuotes(t *testing.T, quotes []Quote, order string) []Quote {
+	compare, err := QuoteOrderFunc(order)
+	assert.NoError(t, err)
+
+	quotes = slices.Clone(quotes)
+	slices.SortStableFunc(quotes, compare)
+
+	return quotes
+}
+
+func TestQuoteOrderFunc_scene(t *testing.T) {
+	quotes := slices.Clone(testQuotes)
+	slices.Reverse(quotes)
+
+	quotes = sortQuotes(t, quotes, QuoteOrderScene)
+	assert.Equal(t, "I lost the biggest sale of the year today.", quotes[8].Quote)
+	assert.Equal(t, 1, quotes[0].Scene)
+	// Lines within a scene keep their relative order.
+	assert.Equal(t, "So you've come to the master for guidance?", quotes[0].Quote)
+	assert.Equal(t, "All right Jim. Your quarterlies look very good.", quotes[2].Quote)
+
+	assert.Equal(t, quotes, sortQuotes(t, quotes, ""))
+}
+
+func TestQuoteOrderFunc_character(t *testing.T) {
+	quotes := sortQuotes(t, testQuotes, QuoteOrderCharacter)
+
+	var characters []string
+	for _, q := range quotes {
+		characters = append(characters, q.Character)
+	}
+	assert.Equal(t, []string{"Dwight", "Dwight", "Jim", "Jim", "Michael", "Michael", "Michael", "Michael", "Pam"}, characters)
+	assert.Equal(t, "Whassup!", quotes[0].Quote)
+}
+
+func TestQuoteOrderFunc_length(t *testing.T) {
+	quotes := sortQuotes(t, testQuotes, QuoteOrderLength)
+
+	assert.Equal(t, "I lost the biggest sale of the year today.", quotes[0].Quote)
+	assert.Equal(t, "Whassup!", quotes[8].Quote)
+}
+
+func TestQuoteOrderFunc_unsupported(t *testing.T) {
+	_, err := QuoteOrderFunc("sentiment")
+	assert.ErrorContains(t, err, `unsupported quote order "sentiment"`)
+}
+
+func TestPage(t *testing.T) {
+	assert.Equal(t, testQuotes, Page(testQuotes, 0, 0))
+	assert.Equal(t, testQuotes[2:5], Page(testQuotes, 2, 3))
+	assert.Equal(t, testQuotes[7:], Page(testQuotes, 7, 5))
+	assert.Empty(t, Page(testQuotes, 9, 5))
+}
+
+func TestKeyQuotes(t *testing.T) {
+	keyed := KeyQuotes(testQuotes)
+
+	var keys []string
+	for _, q := range keyed {
+		keys = append(keys, q.Key)
+	}
+	assert.Equal(t, []string{
+		"s1e1sc1-1", "s1e1sc1-2", "s1e1sc1-3",
+		"s1e1sc2-1", "s1e1sc2-2", "s1e1sc2-3",
+		"s1e2sc1-1", "s1e2sc1-2",
+		"s1e2sc2-1",
+	}, keys)
+	assert.Equal(t, testQuotes[4], keyed[4].Quote)
+	assert.Equal(t, 2, keyed[4].Line)
+}
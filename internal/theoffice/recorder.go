@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// RecorderModeEnvVar names the environment variable acceptance tests
+	// read a Recorder's mode from.
+	RecorderModeEnvVar = "THEOFFICE_VCR_MODE"
+
+	// RecorderModeRecord sends requests to the API and saves each
+	// interaction to the cassette.
+	RecorderModeRecord = "record"
+
+	// RecorderModeReplay serves responses from the cassette and never
+	// touches the network.
+	RecorderModeReplay = "replay"
+
+	// RecorderModePassthrough sends requests to the API without recording.
+	RecorderModePassthrough = ""
+)
+
+// Cassette is a set of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records interactions with the API
+// to a cassette file, or replays them from one. Requests are matched on
+// method and path only, so a cassette recorded against one endpoint can be
+// replayed against any other.
+type Recorder struct {
+	mode string
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecorder returns a Recorder for the cassette at path. Requests that are
+// not replayed are sent through next, or http.DefaultTransport when nil.
+func NewRecorder(mode, path string, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		next:     next,
+		cassette: &Cassette{},
+	}
+
+	switch mode {
+	case RecorderModePassthrough, RecorderModeRecord:
+	case RecorderModeReplay:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(b, r.cassette); err != nil {
+			return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported %s %q, expected %q or %q", RecorderModeEnvVar, mode, RecorderModeRecord, RecorderModeReplay)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode the Recorder was created with.
+func (r *Recorder) Mode() string {
+	return r.mode
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := req.URL.RequestURI()
+
+	if r.mode == RecorderModeReplay {
+		interaction, ok := r.find(req.Method, path)
+		if !ok {
+			return nil, fmt.Errorf("cassette %s has no interaction for %s %s", r.path, req.Method, path)
+		}
+
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	res, err := r.next.RoundTrip(req)
+	if err != nil || r.mode != RecorderModeRecord {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   path,
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       string(body),
+		},
+	}
+
+	// Only the latest response for a request is kept so that retries and
+	// repeated reads across test steps don't bloat the cassette.
+	for i, existing := range r.cassette.Interactions {
+		if existing.Request == interaction.Request {
+			r.cassette.Interactions[i] = interaction
+			return res, nil
+		}
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+
+	return res, nil
+}
+
+func (r *Recorder) find(method, path string) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Request.Method == method && interaction.Request.Path == path {
+			return interaction, true
+		}
+	}
+
+	return Interaction{}, false
+}
+
+// Stop writes the cassette to disk when recording. It is a no-op in the other
+// modes, and when nothing was recorded.
+func (r *Recorder) Stop() error {
+	if r.mode != RecorderModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.cassette.Interactions) == 0 {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/season/1/episode/1", r.URL.Path)
+
+		_, err := w.Write([]byte(`[{"season": 1,"episode": 1,"scene": 1,"episode_name": "Pilot","character": "Michael","quote": "All right Jim."}]`))
+		assert.NoError(t, err)
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassettes", "quotes.json")
+
+	recorder, err := NewRecorder(RecorderModeRecord, path, nil)
+	assert.NoError(t, err)
+
+	c, err := NewClient(&Config{
+		Address:   srv.URL,
+		Transport: recorder,
+	})
+	assert.NoError(t, err)
+
+	_, err = c.GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	_, err = c.GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Stop())
+	assert.Equal(t, 2, calls)
+
+	srv.Close()
+
+	replayer, err := NewRecorder(RecorderModeReplay, path, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(replayer.cassette.Interactions))
+
+	c, err = NewClient(&Config{
+		Address:   "https://replay.test",
+		Transport: replayer,
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, 1, len(resp.Quotes))
+	assert.Equal(t, "Michael", resp.Quotes[0].Character)
+	assert.Equal(t, "All right Jim.", resp.Quotes[0].Quote)
+}
+
+func TestRecorder_replayMissingInteraction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+
+	recorder, err := NewRecorder(RecorderModeRecord, path, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	}))
+	assert.NoError(t, err)
+
+	_, err = recorder.RoundTrip(httptest.NewRequest("GET", "https://example.test/season/1/format/quotes", nil))
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Stop())
+
+	replayer, err := NewRecorder(RecorderModeReplay, path, nil)
+	assert.NoError(t, err)
+
+	_, err = replayer.RoundTrip(httptest.NewRequest("GET", "https://example.test/season/2/format/quotes", nil))
+	assert.ErrorContains(t, err, "has no interaction for GET /season/2/format/quotes")
+}
+
+func TestRecorder_replayMissingCassette(t *testing.T) {
+	_, err := NewRecorder(RecorderModeReplay, filepath.Join(t.TempDir(), "missing.json"), nil)
+	assert.ErrorContains(t, err, "reading cassette")
+}
+
+func TestRecorder_invalidMode(t *testing.T) {
+	_, err := NewRecorder("rewind", "unused.json", nil)
+	assert.ErrorContains(t, err, `unsupported THEOFFICE_VCR_MODE "rewind"`)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"slices"
+	"strings"
+)
+
+// Scene is the dialogue of a single scene of an episode.
+type Scene struct {
+	Season      int
+	Episode     int
+	EpisodeName string
+	Number      int
+
+	// Lines are the scene's quotes in the order they were spoken.
+	Lines []Quote
+
+	// Characters are the characters who speak in the scene, in the order
+	// they first speak. Each speaker of a line said by several counts.
+	Characters []string
+
+	WordCount int
+}
+
+// GroupScenes groups quotes into scenes, in the order each scene first
+// appears. Quotes keep their relative order within a scene.
+func GroupScenes(quotes []Quote) []Scene {
+	type key struct{ season, episode, scene int }
+
+	var scenes []Scene
+	index := map[key]int{}
+	for _, q := range quotes {
+		k := key{q.Season, q.Episode, q.Scene}
+		i, ok := index[k]
+		if !ok {
+			i = len(scenes)
+			index[k] = i
+			scenes = append(scenes, Scene{
+				Season:      q.Season,
+				Episode:     q.Episode,
+				EpisodeName: q.EpisodeName,
+				Number:      q.Scene,
+			})
+		}
+
+		s := &scenes[i]
+		s.Lines = append(s.Lines, q)
+		s.WordCount += WordCount(q.Quote)
+		for _, speaker := range q.SpeakerNames() {
+			if !slices.Contains(s.Characters, speaker) {
+				s.Characters = append(s.Characters, speaker)
+			}
+		}
+	}
+
+	return scenes
+}
+
+// WordCount returns the number of whitespace-separated words in s.
+func WordCount(s string) int {
+	return len(strings.Fields(s))
+}
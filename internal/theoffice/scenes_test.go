@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupScenes(t *testing.T) {
+	scenes := GroupScenes(testQuotes)
+
+	assert.Equal(t, 4, len(scenes))
+
+	assert.Equal(t, 1, scenes[0].Season)
+	assert.Equal(t, 1, scenes[0].Episode)
+	assert.Equal(t, "Pilot", scenes[0].EpisodeName)
+	assert.Equal(t, 1, scenes[0].Number)
+	assert.Equal(t, testQuotes[0:3], scenes[0].Lines)
+	assert.Equal(t, []string{"Michael", "Jim"}, scenes[0].Characters)
+	assert.Equal(t, 24, scenes[0].WordCount)
+
+	assert.Equal(t, 2, scenes[1].Number)
+	assert.Equal(t, []string{"Pam", "Dwight", "Michael"}, scenes[1].Characters)
+
+	assert.Equal(t, 2, scenes[2].Episode)
+	assert.Equal(t, 1, scenes[2].Number)
+	assert.Equal(t, 2, scenes[3].Number)
+	assert.Equal(t, 1, len(scenes[3].Lines))
+}
+
+func TestGroupScenes_interleaved(t *testing.T) {
+	quotes := []Quote{
+		{Season: 1, Episode: 1, Scene: 2, Character: "Pam", Quote: "Dunder Mifflin, this is Pam."},
+		{Season: 1, Episode: 1, Scene: 1, Character: "Michael", Quote: "Hello."},
+		{Season: 1, Episode: 1, Scene: 2, Character: "Pam", Quote: "Yes, one moment."},
+	}
+
+	scenes := GroupScenes(quotes)
+
+	assert.Equal(t, 2, len(scenes))
+	assert.Equal(t, 2, scenes[0].Number)
+	assert.Equal(t, []Quote{quotes[0], quotes[2]}, scenes[0].Lines)
+	assert.Equal(t, []string{"Pam"}, scenes[0].Characters)
+	assert.Equal(t, 8, scenes[0].WordCount)
+	assert.Equal(t, 1, scenes[1].Number)
+}
+
+func TestWordCount(t *testing.T) {
+	assert.Equal(t, 0, WordCount(""))
+	assert.Equal(t, 0, WordCount("   "))
+	assert.Equal(t, 1, WordCount("Question."))
+	assert.Equal(t, 6, WordCount("Identity theft is not a joke,"))
+}
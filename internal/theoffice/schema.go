@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxReportedViolations is the number of violations a SchemaError lists
+// before summarizing the rest.
+const maxReportedViolations = 10
+
+// SchemaError reports how a response doesn't match the API's schema, such as
+// when a field was renamed and would otherwise decode to its zero value. With
+// StrictDecoding, the response is returned along with it, so that callers may
+// choose to carry on.
+type SchemaError struct {
+	// Path is the path of the request the response answered.
+	Path string
+
+	Violations []string
+}
+
+func (e *SchemaError) Error() string {
+	violations := e.Violations
+	var more string
+	if len(violations) > maxReportedViolations {
+		more = fmt.Sprintf("\n- and %d more", len(violations)-maxReportedViolations)
+		violations = violations[:maxReportedViolations]
+	}
+
+	return fmt.Sprintf("GET %s: response does not match the API schema:\n- %s%s", e.Path, strings.Join(violations, "\n- "), more)
+}
+
+// decode decodes body into v. With StrictDecoding, the fields of body that v
+// has no field for are reported in a SchemaError, once body is decoded
+// without them.
+func (c *Client) decode(path string, body []byte, v any) error {
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(v); err != nil || !c.strictDecoding {
+		return err
+	}
+
+	t := reflect.TypeOf(v).Elem()
+	name, ok := responseNames[t]
+	if !ok {
+		name = "response"
+	}
+
+	if violations := unknownFields(name, body, t); len(violations) > 0 {
+		return &SchemaError{Path: path, Violations: violations}
+	}
+
+	return nil
+}
+
+// responseNames names the responses decode reports unknown fields of, like
+// the violations validateQuotes and validateConnections report.
+var responseNames = map[reflect.Type]string{
+	reflect.TypeFor[[]Quote]():      "quotes",
+	reflect.TypeFor[[]Connection](): "connections",
+}
+
+// unknownFields returns the fields of the JSON objects in raw, which decodes
+// into t, that t has no field for, prefixed with where they were found. Like
+// encoding/json, field names are matched ignoring case.
+func unknownFields(prefix string, raw json.RawMessage, t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var violations []string
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil
+		}
+		for i, item := range items {
+			violations = append(violations, unknownFields(fmt.Sprintf("%s[%d]", prefix, i), item, t.Elem())...)
+		}
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil
+		}
+
+		known := jsonFields(t)
+		for _, key := range slices.Sorted(maps.Keys(fields)) {
+			i := slices.IndexFunc(known, func(f reflect.StructField) bool {
+				return strings.EqualFold(jsonName(f), key)
+			})
+			if i < 0 {
+				violations = append(violations, fmt.Sprintf("%s: unknown field %q", prefix, key))
+				continue
+			}
+			violations = append(violations, unknownFields(prefix+": "+key, fields[key], known[i].Type)...)
+		}
+	}
+
+	return violations
+}
+
+// jsonFields returns the fields of struct type t that encoding/json decodes.
+func jsonFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for _, f := range reflect.VisibleFields(t) {
+		if f.IsExported() && !f.Anonymous && f.Tag.Get("json") != "-" {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// jsonName returns the name encoding/json gives field f.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+
+	return name
+}
+
+// withViolations adds violations found in the response to path to err, the
+// error of the request. Errors other than a SchemaError are returned as is.
+func withViolations(err error, path string, violations []string) error {
+	var schemaErr *SchemaError
+	switch {
+	case errors.As(err, &schemaErr):
+		schemaErr.Violations = append(schemaErr.Violations, violations...)
+		return schemaErr
+	case err != nil:
+		return err
+	case len(violations) > 0:
+		return &SchemaError{Path: path, Violations: violations}
+	}
+
+	return nil
+}
+
+// validateQuotes returns how quotes, the response to a request for season
+// and episode, or every episode when zero, violate the API's schema.
+func validateQuotes(quotes []Quote, season, episode int) []string {
+	var violations []string
+	for i, q := range quotes {
+		report := func(format string, args ...any) {
+			violations = append(violations, fmt.Sprintf("quotes[%d]: ", i)+fmt.Sprintf(format, args...))
+		}
+
+		switch {
+		case q.Season != season:
+			report("season is %d, not the requested %d", q.Season, season)
+		case episode > 0 && q.Episode != episode:
+			report("episode is %d, not the requested %d", q.Episode, episode)
+		case !ValidEpisode(q.Season, q.Episode):
+			report("episode %d is not an episode of season %d", q.Episode, q.Season)
+		}
+		if q.Scene < 1 {
+			report("scene must be at least 1, got %d", q.Scene)
+		}
+		if q.EpisodeName == "" {
+			report("missing episode_name")
+		}
+		if q.Character == "" {
+			report("missing character")
+		}
+		if q.Quote == "" {
+			report("missing quote")
+		}
+	}
+
+	return violations
+}
+
+// validateConnections returns how connections, the response to a request
+// for season, violate the API's schema.
+func validateConnections(connections []Connection, season int) []string {
+	var violations []string
+	for i, conn := range connections {
+		report := func(format string, args ...any) {
+			violations = append(violations, fmt.Sprintf("connections[%d]: ", i)+fmt.Sprintf(format, args...))
+		}
+
+		if !ValidEpisode(season, conn.Episode) {
+			report("episode %d is not an episode of season %d", conn.Episode, season)
+		}
+		if conn.EpisodeName == "" {
+			report("missing episode_name")
+		}
+		for j, l := range conn.Links {
+			if l.Source == "" || l.Target == "" {
+				report("links[%d]: missing source or target", j)
+			}
+			if l.Value < 1 {
+				report("links[%d]: value must be at least 1, got %d", j, l.Value)
+			}
+		}
+		for j, n := range conn.Nodes {
+			if n.ID == "" {
+				report("nodes[%d]: missing id", j)
+			}
+		}
+	}
+
+	return violations
+}
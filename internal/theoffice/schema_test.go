@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStrictClient(t *testing.T, body string) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(&Config{
+		Address:        srv.URL,
+		StrictDecoding: true,
+	})
+	assert.NoError(t, err)
+
+	return c
+}
+
+func TestClientStrictDecoding_valid(t *testing.T) {
+	c := newStrictClient(t, `[{"season": 1,"episode": 2,"scene": 1,"episode_name": "Diversity Day","character": "Dwight","quote": "Question."}]`)
+
+	resp, err := c.GetQuotes(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Quotes))
+}
+
+func TestClientStrictDecoding_unknownField(t *testing.T) {
+	// The character field was renamed.
+	c := newStrictClient(t, `[{"season": 1,"episode": 2,"scene": 1,"episode_name": "Diversity Day","speaker": "Dwight","quote": "Question."}]`)
+
+	resp, err := c.GetQuotes(context.Background(), 1, 2)
+
+	var schemaErr *SchemaError
+	assert.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, "/season/1/episode/2", schemaErr.Path)
+	assert.Equal(t, []string{`quotes[0]: unknown field "speaker"`, "quotes[0]: missing character"}, schemaErr.Violations)
+
+	// The response is still returned, decoded without the unknown field.
+	assert.Equal(t, 1, len(resp.Quotes))
+	assert.Equal(t, "Question.", resp.Quotes[0].Quote)
+}
+
+func TestClientStrictDecoding_unknownFields(t *testing.T) {
+	c := newStrictClient(t, `[
+		{"episode": 1,"episode_name": "Pilot","Links": [{"source": "Michael","target": "Jim","weight": 1,"value": 1}],"nodes": [{"id": "Jim","group": 1}],"season": 1},
+		{"episode": 2,"episode_name": "Diversity Day","links": [],"nodes": [{"id": "Jim","label": "Jim"}]}
+	]`)
+
+	resp, err := c.GetConnections(context.Background(), 1)
+
+	var schemaErr *SchemaError
+	assert.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []string{
+		`connections[0]: Links[0]: unknown field "weight"`,
+		`connections[0]: nodes[0]: unknown field "group"`,
+		`connections[0]: unknown field "season"`,
+		`connections[1]: nodes[0]: unknown field "label"`,
+	}, schemaErr.Violations)
+	assert.Equal(t, 2, len(resp.Connections))
+}
+
+func TestClientStrictDecoding_quoteRanges(t *testing.T) {
+	c := newStrictClient(t, `[
+		{"season": 2,"episode": 1,"scene": 1,"episode_name": "The Dundies","character": "Michael","quote": "Hi."},
+		{"season": 1,"episode": 7,"scene": 0,"episode_name": "Pilot","character": "Michael","quote": "Hi."}
+	]`)
+
+	_, err := c.GetQuotes(context.Background(), 1, 0)
+
+	var schemaErr *SchemaError
+	assert.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []string{
+		"quotes[0]: season is 2, not the requested 1",
+		"quotes[1]: episode 7 is not an episode of season 1",
+		"quotes[1]: scene must be at least 1, got 0",
+	}, schemaErr.Violations)
+}
+
+func TestClientStrictDecoding_connections(t *testing.T) {
+	c := newStrictClient(t, `[{"episode": 0,"episode_name": "Pilot","links": [{"source": "Michael","value": 0}],"nodes": [{}]}]`)
+
+	_, err := c.GetConnections(context.Background(), 1)
+
+	var schemaErr *SchemaError
+	assert.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []string{
+		"connections[0]: episode 0 is not an episode of season 1",
+		"connections[0]: links[0]: missing source or target",
+		"connections[0]: links[0]: value must be at least 1, got 0",
+		"connections[0]: nodes[0]: missing id",
+	}, schemaErr.Violations)
+}
+
+func TestClientStrictDecoding_disabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`[{"season": 1,"speaker": "Dwight"}]`))
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&Config{
+		Address: srv.URL,
+	})
+	assert.NoError(t, err)
+
+	resp, err := c.GetQuotes(context.Background(), 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "", resp.Quotes[0].Character)
+}
+
+func TestSchemaError_truncated(t *testing.T) {
+	var violations []string
+	for i := range maxReportedViolations + 2 {
+		violations = append(violations, fmt.Sprintf("quotes[%d]: missing quote", i))
+	}
+
+	msg := (&SchemaError{Path: "/season/1/format/quotes", Violations: violations}).Error()
+	assert.True(t, strings.HasPrefix(msg, "GET /season/1/format/quotes: response does not match the API schema:\n- quotes[0]: missing quote"))
+	assert.True(t, strings.HasSuffix(msg, "- quotes[9]: missing quote\n- and 2 more"))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// negationScalar scales, and flips, the valence of a word preceded by a
+	// negation such as "not good".
+	negationScalar = -0.74
+
+	// negationWindow is the number of words before a valenced word that are
+	// searched for a negation.
+	negationWindow = 3
+
+	// intensifierBoost is added to the magnitude of a word's valence when the
+	// word directly follows an intensifier such as "very".
+	intensifierBoost = 0.5
+
+	// sentimentAlpha approximates the maximum expected sum of valences, and
+	// controls how quickly scores approach -1 and 1 when normalized.
+	sentimentAlpha = 6
+)
+
+// sentimentLexiconData maps words to their valence, from -3 (most negative)
+// to 3 (most positive), one whitespace separated pair per line.
+//
+//go:embed sentiment_lexicon.txt
+var sentimentLexiconData string
+
+var sentimentLexicon = map[string]float64{}
+
+var intensifiers = map[string]bool{
+	"absolutely": true, "completely": true, "extremely": true, "incredibly": true, "really": true,
+	"so": true, "super": true, "too": true, "totally": true, "truly": true, "very": true,
+}
+
+var negations = map[string]bool{
+	"cannot": true, "neither": true, "never": true, "no": true, "nobody": true,
+	"none": true, "nor": true, "not": true, "nothing": true, "nowhere": true, "without": true,
+}
+
+func init() {
+	s := bufio.NewScanner(strings.NewReader(sentimentLexiconData))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			panic(fmt.Sprintf("sentiment lexicon line %d: expected a word and a valence, got %q", n, line))
+		}
+		valence, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			panic(fmt.Sprintf("sentiment lexicon line %d: %s", n, err))
+		}
+		sentimentLexicon[fields[0]] = valence
+	}
+}
+
+// Sentiment scores the sentiment of text from -1 (most negative) to 1 (most
+// positive), with 0 for neutral text. The valences of the words found in the
+// bundled lexicon are summed, after flipping negated words and boosting
+// intensified ones, and the sum is normalized into the score. Scores are
+// rounded to four decimal places.
+func Sentiment(text string) float64 {
+	tokens := Tokenize(text)
+
+	var sum float64
+	for i, t := range tokens {
+		valence, ok := sentimentLexicon[t]
+		if !ok {
+			continue
+		}
+
+		if i > 0 && intensifiers[tokens[i-1]] {
+			valence += math.Copysign(intensifierBoost, valence)
+		}
+		if negated(tokens[max(0, i-negationWindow):i]) {
+			valence *= negationScalar
+		}
+
+		sum += valence
+	}
+
+	score := sum / math.Sqrt(sum*sum+sentimentAlpha)
+
+	return math.Round(score*1e4) / 1e4
+}
+
+// negated reports whether any of tokens is a negation.
+func negated(tokens []string) bool {
+	for _, t := range tokens {
+		if negations[t] || strings.HasSuffix(t, "n't") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentiment(t *testing.T) {
+	assert.Equal(t, 0.898, Sentiment("Tonight is a happy night. Pam, congratulations!"))
+	assert.Equal(t, -0.7746, Sentiment("I hate parties."))
+	assert.Equal(t, 0.0, Sentiment("Tell him I'm not here."))
+	assert.Equal(t, 0.0, Sentiment(""))
+}
+
+func TestSentiment_negation(t *testing.T) {
+	assert.Equal(t, 0.6325, Sentiment("He's great."))
+	assert.Equal(t, -0.5171, Sentiment("He's not that great."))
+	assert.Equal(t, 0.6715, Sentiment("I don't hate parties."))
+}
+
+func TestSentiment_intensifier(t *testing.T) {
+	assert.Greater(t, Sentiment("She is very happy."), Sentiment("She is happy."))
+	assert.Less(t, Sentiment("This is so stupid."), Sentiment("This is stupid."))
+}
+
+func TestSentiment_bounds(t *testing.T) {
+	score := Sentiment("Best, best, best! Amazing, wonderful, fantastic, awesome, outstanding, perfect.")
+	assert.Greater(t, score, 0.99)
+	assert.LessOrEqual(t, score, 1.0)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultSimilarLimit is the number of results returned by a similarity
+// search.
+const DefaultSimilarLimit = 10
+
+type SimilarOptions struct {
+	// Limit is the maximum number of results. Defaults to
+	// DefaultSimilarLimit.
+	Limit int
+
+	// MinScore drops results less similar than it.
+	MinScore float64
+
+	// Exclude, when set, drops the quotes it returns true for, such as the
+	// quote the search started from.
+	Exclude func(Quote) bool
+}
+
+// Similar returns the quotes most similar to text, ordered by descending
+// cosine similarity of their TF-IDF vectors and then by their order in the
+// index. Stopwords are ignored, so text made only of stopwords matches
+// nothing. Scores range from 0 to 1 and are rounded to four decimal places.
+func (idx *QuoteIndex) Similar(text string, opts SimilarOptions) []SearchResult {
+	if opts.Limit < 1 {
+		opts.Limit = DefaultSimilarLimit
+	}
+	idx.normsOnce.Do(idx.computeNorms)
+
+	query := map[string]float64{}
+	for _, t := range Tokenize(text) {
+		if !stopwords[t] && idx.postings[t] != nil {
+			query[t]++
+		}
+	}
+
+	var queryNorm float64
+	dots := map[int]float64{}
+	for t, tf := range query {
+		idf := idx.idf(t)
+		weight := tf * idf
+		queryNorm += weight * weight
+
+		for doc, positions := range idx.postings[t] {
+			dots[doc] += weight * float64(len(positions)) * idf
+		}
+	}
+	queryNorm = math.Sqrt(queryNorm)
+
+	docs := make([]int, 0, len(dots))
+	scores := map[int]float64{}
+	for doc, dot := range dots {
+		if opts.Exclude != nil && opts.Exclude(idx.quotes[doc]) {
+			continue
+		}
+		docs = append(docs, doc)
+		scores[doc] = math.Round(dot/(queryNorm*idx.norms[doc])*1e4) / 1e4
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if scores[docs[i]] != scores[docs[j]] {
+			return scores[docs[i]] > scores[docs[j]]
+		}
+		return docs[i] < docs[j]
+	})
+
+	var results []SearchResult
+	for _, doc := range docs {
+		if scores[doc] < opts.MinScore || len(results) == opts.Limit {
+			break
+		}
+		results = append(results, SearchResult{Quote: idx.quotes[doc], Score: scores[doc]})
+	}
+
+	return results
+}
+
+// idf returns the smoothed inverse document frequency of term.
+func (idx *QuoteIndex) idf(term string) float64 {
+	n := float64(len(idx.quotes))
+	df := float64(len(idx.postings[term]))
+
+	return math.Log((1+n)/(1+df)) + 1
+}
+
+func (idx *QuoteIndex) computeNorms() {
+	idx.norms = make([]float64, len(idx.quotes))
+	for t, docs := range idx.postings {
+		if stopwords[t] {
+			continue
+		}
+
+		idf := idx.idf(t)
+		for doc, positions := range docs {
+			weight := float64(len(positions)) * idf
+			idx.norms[doc] += weight * weight
+		}
+	}
+
+	for doc, norm := range idx.norms {
+		idx.norms[doc] = math.Sqrt(norm)
+	}
+}
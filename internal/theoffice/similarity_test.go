@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIndex_Similar(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	results := idx.Similar("What is the purpose of life?", SimilarOptions{})
+	assert.Equal(t, []string{
+		"The purpose is to understand each other.",
+		"Question. What is the purpose of this exercise?",
+	}, searchQuotes(results))
+	assert.Equal(t, 0.6453, results[0].Score)
+	assert.Equal(t, 0.5127, results[1].Score)
+
+	results = idx.Similar("whassup", SimilarOptions{})
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, 1.0, results[0].Score)
+}
+
+func TestQuoteIndex_Similar_ignoresStopwords(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	assert.Empty(t, idx.Similar("What is this?", SimilarOptions{}))
+	assert.Empty(t, idx.Similar("", SimilarOptions{}))
+}
+
+func TestQuoteIndex_Similar_options(t *testing.T) {
+	idx := NewQuoteIndex(testQuotes)
+
+	results := idx.Similar("whassup purpose", SimilarOptions{
+		Exclude: func(q Quote) bool { return q.Character == "Dwight" },
+	})
+	assert.Equal(t, []string{"Whassup!", "The purpose is to understand each other."}, searchQuotes(results))
+
+	assert.Equal(t, searchQuotes(results[:1]), searchQuotes(idx.Similar("whassup purpose", SimilarOptions{
+		Limit:   1,
+		Exclude: func(q Quote) bool { return q.Character == "Dwight" },
+	})))
+	assert.Equal(t, 3, len(idx.Similar("whassup purpose", SimilarOptions{MinScore: 0.4})))
+}
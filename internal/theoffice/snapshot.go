@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+//go:generate go run ./snapshotgen -dir snapshot
+
+// bundledSnapshot holds the snapshot committed to the repository, which is
+// refreshed with `make snapshot`. Empty files bundle no snapshot.
+//
+//go:embed snapshot/*.json
+var bundledSnapshot embed.FS
+
+// ErrNoSnapshot is returned by BundledSnapshot when the provider was built
+// without a snapshot.
+var ErrNoSnapshot = errors.New("this build of the provider bundles no snapshot of the API; run `make snapshot` to take one")
+
+// Snapshot is a copy of the quotes and connections served by the API.
+type Snapshot struct {
+	Quotes      []Quote
+	Connections map[int][]Connection
+}
+
+// SnapshotSeason is the connections of a season, as stored in a snapshot's
+// connections.json.
+type SnapshotSeason struct {
+	Season      int          `json:"season"`
+	Connections []Connection `json:"connections"`
+}
+
+// BundledSnapshot returns the snapshot of the API bundled with the provider.
+func BundledSnapshot() (*Snapshot, error) {
+	sub, err := fs.Sub(bundledSnapshot, "snapshot")
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadSnapshot(sub)
+}
+
+// ReadSnapshot reads a snapshot from the quotes.json and connections.json
+// files of fsys. It returns ErrNoSnapshot when the snapshot has no quotes.
+func ReadSnapshot(fsys fs.FS) (*Snapshot, error) {
+	s := &Snapshot{
+		Connections: map[int][]Connection{},
+	}
+
+	b, err := fs.ReadFile(fsys, "quotes.json")
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &s.Quotes); err != nil {
+		return nil, fmt.Errorf("decoding snapshot quotes: %w", err)
+	}
+	if len(s.Quotes) == 0 {
+		return nil, ErrNoSnapshot
+	}
+
+	b, err = fs.ReadFile(fsys, "connections.json")
+	if err != nil {
+		return nil, err
+	}
+	var seasons []SnapshotSeason
+	if err := json.Unmarshal(b, &seasons); err != nil {
+		return nil, fmt.Errorf("decoding snapshot connections: %w", err)
+	}
+	for _, season := range seasons {
+		s.Connections[season.Season] = season.Connections
+	}
+
+	return s, nil
+}
+
+// SeasonQuotes returns the quotes of season, in the order the API serves
+// them.
+func (s *Snapshot) SeasonQuotes(season int) []Quote {
+	var quotes []Quote
+	for _, q := range s.Quotes {
+		if q.Season == season {
+			quotes = append(quotes, q)
+		}
+	}
+
+	return quotes
+}
+
+// SeasonConnections returns the connections of season.
+func (s *Snapshot) SeasonConnections(season int) []Connection {
+	return s.Connections[season]
+}
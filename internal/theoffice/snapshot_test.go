@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSnapshot(t *testing.T) {
+	fsys := fstest.MapFS{
+		"quotes.json": {Data: []byte(`[
+			{"season":1,"episode":1,"scene":1,"episode_name":"Pilot","character":"Michael","quote":"All right Jim."},
+			{"season":2,"episode":1,"scene":1,"episode_name":"The Dundies","character":"Pam","quote":"Hi."},
+			{"season":1,"episode":1,"scene":2,"episode_name":"Pilot","character":"Jim","quote":"Oh, I told you."}
+		]`)},
+		"connections.json": {Data: []byte(`[
+			{"season":1,"connections":[{"episode":1,"episode_name":"Pilot","links":[{"source":"Jim","target":"Michael","value":4}],"nodes":[{"id":"Jim"},{"id":"Michael"}]}]}
+		]`)},
+	}
+
+	s, err := ReadSnapshot(fsys)
+	assert.NoError(t, err)
+
+	quotes := s.SeasonQuotes(1)
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, "Michael", quotes[0].Character)
+	assert.Equal(t, "Jim", quotes[1].Character)
+	assert.Len(t, s.SeasonQuotes(2), 1)
+	assert.Empty(t, s.SeasonQuotes(3))
+
+	connections := s.SeasonConnections(1)
+	assert.Len(t, connections, 1)
+	assert.Equal(t, []Link{{Source: "Jim", Target: "Michael", Value: 4}}, connections[0].Links)
+	assert.Empty(t, s.SeasonConnections(2))
+}
+
+func TestReadSnapshot_empty(t *testing.T) {
+	fsys := fstest.MapFS{
+		"quotes.json":      {Data: []byte("[]\n")},
+		"connections.json": {Data: []byte("[]\n")},
+	}
+
+	_, err := ReadSnapshot(fsys)
+	assert.ErrorIs(t, err, ErrNoSnapshot)
+}
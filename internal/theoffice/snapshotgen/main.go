@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Command snapshotgen writes a snapshot of the API's quotes and connections,
+// which is committed to the repository and bundled with the provider.
+// Responses that don't match the API's schema are kept, with a warning, so
+// that the snapshot records what the API serves.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+)
+
+func main() {
+	var address, dir string
+
+	flag.StringVar(&address, "address", "", "the API endpoint to snapshot, instead of the default one")
+	flag.StringVar(&dir, "dir", "snapshot", "the directory to write quotes.json and connections.json to")
+	flag.Parse()
+
+	client, err := theoffice.NewClient(&theoffice.Config{
+		Address:        address,
+		StrictDecoding: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	var quotes []theoffice.Quote
+	var seasons []theoffice.SnapshotSeason
+	for season := 1; season <= theoffice.NumSeasons; season++ {
+		q, err := client.GetQuotes(ctx, season, 0)
+		if err = warn(err); err != nil {
+			log.Fatalf("reading quotes of season %d: %s", season, err)
+		}
+		quotes = append(quotes, q.Quotes...)
+
+		c, err := client.GetConnections(ctx, season)
+		if err = warn(err); err != nil {
+			log.Fatalf("reading connections of season %d: %s", season, err)
+		}
+		seasons = append(seasons, theoffice.SnapshotSeason{Season: season, Connections: c.Connections})
+	}
+
+	write(filepath.Join(dir, "quotes.json"), quotes)
+	write(filepath.Join(dir, "connections.json"), seasons)
+}
+
+// warn logs err when responses don't match the API's schema, and returns
+// the other errors.
+func warn(err error) error {
+	var schemaErr *theoffice.SchemaError
+	if errors.As(err, &schemaErr) {
+		log.Printf("warning: %s", schemaErr)
+		return nil
+	}
+
+	return err
+}
+
+func write(path string, v any) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultSpeakerSeparators split the names of characters saying a line
+// together, such as "Jim & Pam" or "Jim/Pam". Separators such as " and " and
+// ", " also appear within the names of single characters, like "Bob Vance,
+// Vance Refrigeration", so they aren't split by default.
+var DefaultSpeakerSeparators = []string{" & ", "/"}
+
+// sceneGroups are the names, in lowercase, of the built-in groups made of
+// every character speaking in the scene.
+var sceneGroups = []string{"all", "everybody", "everyone", "group"}
+
+// SpeakerOptions configures how a SpeakerParser parses characters.
+type SpeakerOptions struct {
+	// Separators split compound speakers. Defaults to
+	// DefaultSpeakerSeparators when nil, while an empty slice leaves
+	// characters whole.
+	Separators []string
+
+	// Groups maps names of groups to their members, in addition to the
+	// built-in groups such as "Everyone". Groups without members are made of
+	// every other character speaking in the scene. Names are matched
+	// ignoring case and extra whitespace.
+	Groups map[string][]string
+
+	// Names, when set, normalizes the name of each speaker.
+	Names *CharacterNames
+}
+
+// SpeakerParser parses the characters of quotes into the speakers saying
+// them, so that lines said together or by a group are attributed to each of
+// their speakers. A nil *SpeakerParser leaves characters whole.
+type SpeakerParser struct {
+	separators []string
+	groups     map[string][]string
+	names      *CharacterNames
+}
+
+// NewSpeakerParser returns a SpeakerParser configured by opts.
+func NewSpeakerParser(opts SpeakerOptions) *SpeakerParser {
+	p := &SpeakerParser{
+		separators: opts.Separators,
+		groups:     map[string][]string{},
+		names:      opts.Names,
+	}
+	if p.separators == nil {
+		p.separators = DefaultSpeakerSeparators
+	}
+
+	for _, group := range sceneGroups {
+		p.groups[group] = nil
+	}
+	for group, members := range opts.Groups {
+		p.groups[characterKey(group)] = members
+	}
+
+	return p
+}
+
+// SpeakerNames returns the speakers of the quote: Speakers when parsed, or
+// Character alone.
+func (q Quote) SpeakerNames() []string {
+	if q.Speakers != nil || q.Character == "" {
+		return q.Speakers
+	}
+
+	return []string{q.Character}
+}
+
+// split returns the speakers named by character, and the names of the groups
+// made of the scene's speakers it names, whose members aren't returned.
+func (p *SpeakerParser) split(character string) ([]string, []string) {
+	parts := []string{character}
+	for _, sep := range p.separators {
+		var split []string
+		for _, part := range parts {
+			split = append(split, strings.Split(part, sep)...)
+		}
+		parts = split
+	}
+
+	var speakers, groups []string
+	add := func(name string) {
+		name = p.names.Normalize(name)
+		if !slices.Contains(speakers, name) {
+			speakers = append(speakers, name)
+		}
+	}
+	for _, part := range parts {
+		// Separators may be spaced differently in the dataset, such as in
+		// "Jim / Pam", so extra whitespace is collapsed whether or not names
+		// are normalized.
+		part = strings.Join(strings.Fields(part), " ")
+		if part == "" {
+			continue
+		}
+
+		members, ok := p.groups[characterKey(part)]
+		switch {
+		case !ok:
+			add(part)
+		case len(members) == 0:
+			groups = append(groups, part)
+		default:
+			for _, m := range members {
+				add(m)
+			}
+		}
+	}
+
+	return speakers, groups
+}
+
+// Quotes returns a copy of quotes with Speakers set. Groups made of the
+// scene's speakers are every character speaking a line in the scene that
+// isn't said by such a group, or keep the group's name when there are none.
+func (p *SpeakerParser) Quotes(quotes []Quote) []Quote {
+	if p == nil {
+		return quotes
+	}
+
+	type sceneKey struct{ season, episode, scene int }
+
+	parsed := make([]Quote, len(quotes))
+	groupLines := map[int][]string{}
+	sceneSpeakers := map[sceneKey][]string{}
+	for i, q := range quotes {
+		speakers, groups := p.split(q.Character)
+		k := sceneKey{q.Season, q.Episode, q.Scene}
+		if len(groups) > 0 {
+			groupLines[i] = groups
+		} else {
+			for _, s := range speakers {
+				if !slices.Contains(sceneSpeakers[k], s) {
+					sceneSpeakers[k] = append(sceneSpeakers[k], s)
+				}
+			}
+		}
+
+		q.Speakers = speakers
+		parsed[i] = q
+	}
+
+	for i, groups := range groupLines {
+		q := &parsed[i]
+		others := sceneSpeakers[sceneKey{q.Season, q.Episode, q.Scene}]
+		if len(others) == 0 {
+			q.Speakers = append(q.Speakers, groups...)
+		}
+		for _, s := range others {
+			if !slices.Contains(q.Speakers, s) {
+				q.Speakers = append(q.Speakers, s)
+			}
+		}
+	}
+
+	return parsed
+}
+
+// Connections returns a copy of connections where characters naming several
+// speakers are replaced by each of them, merging links and nodes like
+// CharacterNames.Connections. Connections hold no scenes, so groups made of
+// the scene's speakers are left whole.
+func (p *SpeakerParser) Connections(connections []Connection) []Connection {
+	if p == nil {
+		return connections
+	}
+
+	return renameConnections(connections, func(name string) []string {
+		speakers, groups := p.split(name)
+		return append(speakers, groups...)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeakerParser_Quotes(t *testing.T) {
+	p := NewSpeakerParser(SpeakerOptions{
+		Groups: map[string][]string{
+			"Accountants": {"Angela", "Kevin", "Oscar"},
+		},
+		Names: NewCharacterNames(nil, true),
+	})
+
+	quotes := p.Quotes([]Quote{
+		{Season: 1, Episode: 1, Scene: 1, Character: "Michael", Quote: "Conference room, five minutes."},
+		{Season: 1, Episode: 1, Scene: 1, Character: "Jim & Pam", Quote: "Okay."},
+		{Season: 1, Episode: 1, Scene: 1, Character: "Everyone", Quote: "Yes."},
+		{Season: 1, Episode: 1, Scene: 2, Character: "Dwight/Michael Scott", Quote: "Bears."},
+		{Season: 1, Episode: 1, Scene: 2, Character: "accountants", Quote: "No."},
+		{Season: 1, Episode: 1, Scene: 3, Character: "All", Quote: "Surprise!"},
+		{Season: 1, Episode: 1, Scene: 3, Character: "", Quote: "..."},
+	})
+
+	assert.Equal(t, []string{"Michael"}, quotes[0].Speakers)
+	assert.Equal(t, []string{"Jim", "Pam"}, quotes[1].Speakers)
+	assert.Equal(t, []string{"Michael", "Jim", "Pam"}, quotes[2].Speakers)
+	assert.Equal(t, []string{"Dwight", "Michael"}, quotes[3].Speakers)
+	assert.Equal(t, []string{"Angela", "Kevin", "Oscar"}, quotes[4].Speakers)
+	// No one else speaks in the scene, so the group is kept whole.
+	assert.Equal(t, []string{"All"}, quotes[5].Speakers)
+	assert.Empty(t, quotes[6].SpeakerNames())
+
+	// Characters are left as they are.
+	assert.Equal(t, "Jim & Pam", quotes[1].Character)
+}
+
+func TestSpeakerParser_separators(t *testing.T) {
+	quotes := NewSpeakerParser(SpeakerOptions{Separators: []string{" + "}}).Quotes([]Quote{
+		{Character: "Jim & Pam"},
+		{Character: "Jim + Pam"},
+	})
+	assert.Equal(t, []string{"Jim & Pam"}, quotes[0].Speakers)
+	assert.Equal(t, []string{"Jim", "Pam"}, quotes[1].Speakers)
+
+	quotes = NewSpeakerParser(SpeakerOptions{Separators: []string{}}).Quotes([]Quote{{Character: "Jim & Pam"}})
+	assert.Equal(t, []string{"Jim & Pam"}, quotes[0].Speakers)
+
+	// Names holding " and " or ", " are kept whole unless they are separators.
+	quotes = NewSpeakerParser(SpeakerOptions{}).Quotes([]Quote{
+		{Character: "Bob Vance, Vance Refrigeration"},
+		{Character: "Jim and Pam"},
+	})
+	assert.Equal(t, []string{"Bob Vance, Vance Refrigeration"}, quotes[0].Speakers)
+	assert.Equal(t, []string{"Jim and Pam"}, quotes[1].Speakers)
+
+	quotes = NewSpeakerParser(SpeakerOptions{Separators: []string{" and ", ", "}}).Quotes([]Quote{
+		{Character: "Jim, Pam and Dwight"},
+	})
+	assert.Equal(t, []string{"Jim", "Pam", "Dwight"}, quotes[0].Speakers)
+}
+
+func TestSpeakerParser_spacedSeparators(t *testing.T) {
+	p := NewSpeakerParser(SpeakerOptions{})
+
+	quotes := p.Quotes([]Quote{
+		{Character: "Jim / Pam"},
+		{Character: "Jim &  Pam"},
+		{Character: " Dwight "},
+	})
+	assert.Equal(t, []string{"Jim", "Pam"}, quotes[0].Speakers)
+	assert.Equal(t, []string{"Jim", "Pam"}, quotes[1].Speakers)
+	assert.Equal(t, []string{"Dwight"}, quotes[2].Speakers)
+
+	connections := p.Connections([]Connection{{
+		Episode: 1,
+		Links: []Link{
+			{Source: "Jim / Pam", Target: "Jim", Value: 2},
+		},
+		Nodes: []Node{{ID: "Jim / Pam"}, {ID: "Jim"}},
+	}})
+	assert.Equal(t, []Link{{Source: "Pam", Target: "Jim", Value: 2}}, connections[0].Links)
+	assert.Equal(t, []Node{{ID: "Jim"}, {ID: "Pam"}}, connections[0].Nodes)
+}
+
+func TestSpeakerParser_Connections(t *testing.T) {
+	connections := NewSpeakerParser(SpeakerOptions{}).Connections([]Connection{{
+		Episode: 1,
+		Links: []Link{
+			{Source: "Jim & Pam", Target: "Michael", Value: 2},
+			{Source: "Jim", Target: "Michael", Value: 3},
+			{Source: "Everyone", Target: "Michael", Value: 1},
+		},
+		Nodes: []Node{{ID: "Jim & Pam"}, {ID: "Michael"}, {ID: "Jim"}, {ID: "Everyone"}},
+	}})
+
+	assert.Equal(t, []Link{
+		{Source: "Jim", Target: "Michael", Value: 5},
+		{Source: "Pam", Target: "Michael", Value: 2},
+		{Source: "Everyone", Target: "Michael", Value: 1},
+	}, connections[0].Links)
+	assert.Equal(t, []Node{{ID: "Jim"}, {ID: "Pam"}, {ID: "Michael"}, {ID: "Everyone"}}, connections[0].Nodes)
+}
+
+func TestQuote_SpeakerNames(t *testing.T) {
+	assert.Equal(t, []string{"Jim & Pam"}, Quote{Character: "Jim & Pam"}.SpeakerNames())
+	assert.Equal(t, []string{"Jim", "Pam"}, Quote{Character: "Jim & Pam", Speakers: []string{"Jim", "Pam"}}.SpeakerNames())
+	assert.Empty(t, Quote{}.SpeakerNames())
+}
+
+func TestComputeCharacterStats_speakers(t *testing.T) {
+	quotes := NewSpeakerParser(SpeakerOptions{}).Quotes([]Quote{
+		{Season: 1, Episode: 1, Scene: 1, Character: "Michael", Quote: "Hello there."},
+		{Season: 1, Episode: 1, Scene: 1, Character: "Jim & Pam", Quote: "Hi."},
+	})
+
+	stats := ComputeCharacterStats(quotes)
+	assert.Equal(t, 3, len(stats))
+	for _, s := range stats {
+		assert.Equal(t, 1, s.LineCount, s.Character)
+		assert.Equal(t, 2, len(s.CoSpeakers), s.Character)
+	}
+
+	connections, err := BuildConnections(quotes, ConnectionOptions{Weighting: WeightingAdjacent})
+	assert.NoError(t, err)
+	assert.Equal(t, []Link{
+		{Source: "Jim", Target: "Michael", Value: 1},
+		{Source: "Michael", Target: "Pam", Value: 1},
+	}, connections[0].Links)
+	assert.Equal(t, []Node{{ID: "Michael"}, {ID: "Jim"}, {ID: "Pam"}}, connections[0].Nodes)
+}
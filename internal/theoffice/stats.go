@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"sort"
+)
+
+// CharacterStats summarizes the dialogue of a single character.
+type CharacterStats struct {
+	Character string
+	LineCount int
+	WordCount int
+
+	// AverageLineLength is the mean number of words per line.
+	AverageLineLength float64
+
+	// DialogueShare is the fraction of all words spoken that were spoken
+	// by the character.
+	DialogueShare float64
+
+	// Episodes is the number of episodes in which the character speaks.
+	Episodes int
+
+	// CoSpeakers are the other characters who speak in the same scenes,
+	// ordered by the number of scenes shared.
+	CoSpeakers []CoSpeaker
+}
+
+type CoSpeaker struct {
+	Character    string
+	SharedScenes int
+}
+
+// ComputeCharacterStats returns the statistics of every character speaking
+// in quotes, ordered by line count and then by name. Lines said by several
+// speakers count for each of them, so dialogue shares may sum to more than 1.
+func ComputeCharacterStats(quotes []Quote) []CharacterStats {
+	type episodeKey struct{ season, episode int }
+
+	var totalWords int
+	stats := map[string]*CharacterStats{}
+	episodes := map[string]map[episodeKey]bool{}
+	for _, q := range quotes {
+		words := WordCount(q.Quote)
+		totalWords += words
+
+		for _, speaker := range q.SpeakerNames() {
+			s, ok := stats[speaker]
+			if !ok {
+				s = &CharacterStats{Character: speaker}
+				stats[speaker] = s
+				episodes[speaker] = map[episodeKey]bool{}
+			}
+
+			s.LineCount++
+			s.WordCount += words
+			episodes[speaker][episodeKey{q.Season, q.Episode}] = true
+		}
+	}
+
+	shared := map[string]map[string]int{}
+	for _, scene := range GroupScenes(quotes) {
+		for _, a := range scene.Characters {
+			for _, b := range scene.Characters {
+				if a == b {
+					continue
+				}
+				if shared[a] == nil {
+					shared[a] = map[string]int{}
+				}
+				shared[a][b]++
+			}
+		}
+	}
+
+	result := make([]CharacterStats, 0, len(stats))
+	for name, s := range stats {
+		s.AverageLineLength = float64(s.WordCount) / float64(s.LineCount)
+		if totalWords > 0 {
+			s.DialogueShare = float64(s.WordCount) / float64(totalWords)
+		}
+		s.Episodes = len(episodes[name])
+
+		for other, n := range shared[name] {
+			s.CoSpeakers = append(s.CoSpeakers, CoSpeaker{Character: other, SharedScenes: n})
+		}
+		sort.Slice(s.CoSpeakers, func(i, j int) bool {
+			a, b := s.CoSpeakers[i], s.CoSpeakers[j]
+			if a.SharedScenes != b.SharedScenes {
+				return a.SharedScenes > b.SharedScenes
+			}
+			return a.Character < b.Character
+		})
+
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LineCount != result[j].LineCount {
+			return result[i].LineCount > result[j].LineCount
+		}
+		return result[i].Character < result[j].Character
+	})
+
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeCharacterStats(t *testing.T) {
+	stats := ComputeCharacterStats(testQuotes)
+
+	assert.Equal(t, 4, len(stats))
+
+	michael := stats[0]
+	assert.Equal(t, "Michael", michael.Character)
+	assert.Equal(t, 4, michael.LineCount)
+	assert.Equal(t, 24, michael.WordCount)
+	assert.Equal(t, 6.0, michael.AverageLineLength)
+	assert.InDelta(t, 24.0/57.0, michael.DialogueShare, 1e-9)
+	assert.Equal(t, 2, michael.Episodes)
+	assert.Equal(t, []CoSpeaker{
+		{Character: "Dwight", SharedScenes: 2},
+		{Character: "Jim", SharedScenes: 1},
+		{Character: "Pam", SharedScenes: 1},
+	}, michael.CoSpeakers)
+
+	assert.Equal(t, "Dwight", stats[1].Character)
+	assert.Equal(t, 2, stats[1].LineCount)
+	assert.Equal(t, "Jim", stats[2].Character)
+	assert.Equal(t, 2, stats[2].LineCount)
+	assert.Equal(t, 2, stats[2].Episodes)
+
+	pam := stats[3]
+	assert.Equal(t, "Pam", pam.Character)
+	assert.Equal(t, 1, pam.LineCount)
+	assert.Equal(t, 1, pam.Episodes)
+	assert.Equal(t, []CoSpeaker{
+		{Character: "Dwight", SharedScenes: 1},
+		{Character: "Michael", SharedScenes: 1},
+	}, pam.CoSpeakers)
+}
+
+func TestComputeCharacterStats_none(t *testing.T) {
+	assert.Empty(t, ComputeCharacterStats(nil))
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TranscriptFormatFountain renders a Fountain screenplay, see
+	// https://fountain.io/syntax.
+	TranscriptFormatFountain = "fountain"
+
+	// TranscriptFormatMarkdown renders a Markdown document.
+	TranscriptFormatMarkdown = "markdown"
+
+	// TranscriptFormatText renders plain text.
+	TranscriptFormatText = "text"
+)
+
+// TranscriptFormats lists the supported transcript formats.
+var TranscriptFormats = []string{TranscriptFormatFountain, TranscriptFormatMarkdown, TranscriptFormatText}
+
+type TranscriptOptions struct {
+	// Format is the format to render. Defaults to TranscriptFormatFountain.
+	Format string
+
+	// Width wraps dialogue at the given number of columns. Zero disables
+	// wrapping.
+	Width int
+}
+
+// RenderTranscript renders the scenes of an episode, as returned by
+// GroupScenes, into a transcript with a title, scene headings and character
+// cues.
+func RenderTranscript(scenes []Scene, opts TranscriptOptions) (string, error) {
+	if opts.Format == "" {
+		opts.Format = TranscriptFormatFountain
+	}
+
+	var render func(*strings.Builder, []Scene, int)
+	switch opts.Format {
+	case TranscriptFormatFountain:
+		render = renderFountain
+	case TranscriptFormatMarkdown:
+		render = renderMarkdown
+	case TranscriptFormatText:
+		render = renderText
+	default:
+		return "", fmt.Errorf("unsupported transcript format %q, expected one of %s", opts.Format, strings.Join(TranscriptFormats, ", "))
+	}
+
+	if len(scenes) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	render(&b, scenes, opts.Width)
+
+	return b.String(), nil
+}
+
+func renderFountain(b *strings.Builder, scenes []Scene, width int) {
+	fmt.Fprintf(b, "Title: %s\n", scenes[0].EpisodeName)
+	fmt.Fprintf(b, "Episode: Season %d, Episode %d\n", scenes[0].Season, scenes[0].Episode)
+
+	for _, s := range scenes {
+		// A leading period forces a scene heading that doesn't start with
+		// INT. or EXT.
+		fmt.Fprintf(b, "\n.SCENE %d\n", s.Number)
+
+		for _, line := range s.Lines {
+			fmt.Fprintf(b, "\n%s\n", strings.ToUpper(line.Character))
+			for _, l := range wrap(line.Quote, width) {
+				fmt.Fprintln(b, l)
+			}
+		}
+	}
+}
+
+func renderMarkdown(b *strings.Builder, scenes []Scene, width int) {
+	fmt.Fprintf(b, "# %s\n\n", scenes[0].EpisodeName)
+	fmt.Fprintf(b, "*Season %d, Episode %d*\n", scenes[0].Season, scenes[0].Episode)
+
+	for _, s := range scenes {
+		fmt.Fprintf(b, "\n## Scene %d\n", s.Number)
+
+		for _, line := range s.Lines {
+			cue := fmt.Sprintf("**%s:**", line.Character)
+			fmt.Fprintf(b, "\n%s\n", strings.Join(wrap(cue+" "+line.Quote, width), "\n"))
+		}
+	}
+}
+
+// textIndent indents continuation lines of dialogue in plain text transcripts.
+const textIndent = "    "
+
+func renderText(b *strings.Builder, scenes []Scene, width int) {
+	fmt.Fprintf(b, "%s\n", strings.ToUpper(scenes[0].EpisodeName))
+	fmt.Fprintf(b, "Season %d, Episode %d\n", scenes[0].Season, scenes[0].Episode)
+
+	for _, s := range scenes {
+		fmt.Fprintf(b, "\nSCENE %d\n\n", s.Number)
+
+		for _, line := range s.Lines {
+			cue := strings.ToUpper(line.Character) + ":"
+			lines := wrap(cue+" "+line.Quote, width)
+			fmt.Fprintln(b, lines[0])
+			if len(lines) == 1 {
+				continue
+			}
+
+			// Continuation lines are indented, so they're rewrapped to
+			// leave room for it.
+			for _, l := range wrap(strings.Join(lines[1:], " "), width-len(textIndent)) {
+				fmt.Fprintf(b, "%s%s\n", textIndent, l)
+			}
+		}
+	}
+}
+
+// wrap splits s into lines of at most width columns, breaking between words.
+// Words longer than width are kept whole on their own line. A width of zero
+// or less returns s unwrapped.
+func wrap(s string, width int) []string {
+	words := strings.Fields(s)
+	if width <= 0 || len(words) == 0 {
+		return []string{strings.Join(words, " ")}
+	}
+
+	var lines []string
+	line := words[0]
+	for _, w := range words[1:] {
+		if len(line)+1+len(w) > width {
+			lines = append(lines, line)
+			line = w
+			continue
+		}
+		line += " " + w
+	}
+
+	return append(lines, line)
+}
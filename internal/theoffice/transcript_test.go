@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theoffice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTranscript_fountain(t *testing.T) {
+	transcript, err := RenderTranscript(GroupScenes(testQuotes[:4]), TranscriptOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Title: Pilot
+Episode: Season 1, Episode 1
+
+.SCENE 1
+
+MICHAEL
+All right Jim. Your quarterlies look very good.
+
+JIM
+Oh, I told you. I couldn't close it.
+
+MICHAEL
+So you've come to the master for guidance?
+
+.SCENE 2
+
+PAM
+Michael, Todd Packer is on line one.
+`, transcript)
+}
+
+func TestRenderTranscript_markdown(t *testing.T) {
+	transcript, err := RenderTranscript(GroupScenes(testQuotes[6:8]), TranscriptOptions{
+		Format: TranscriptFormatMarkdown,
+		Width:  40,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `# Diversity Day
+
+*Season 1, Episode 2*
+
+## Scene 1
+
+**Dwight:** Question. What is the
+purpose of this exercise?
+
+**Michael:** The purpose is to
+understand each other.
+`, transcript)
+}
+
+func TestRenderTranscript_text(t *testing.T) {
+	transcript, err := RenderTranscript(GroupScenes(testQuotes[:2]), TranscriptOptions{
+		Format: TranscriptFormatText,
+		Width:  30,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `PILOT
+Season 1, Episode 1
+
+SCENE 1
+
+MICHAEL: All right Jim. Your
+    quarterlies look very
+    good.
+JIM: Oh, I told you. I
+    couldn't close it.
+`, transcript)
+}
+
+func TestRenderTranscript_empty(t *testing.T) {
+	transcript, err := RenderTranscript(nil, TranscriptOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", transcript)
+}
+
+func TestRenderTranscript_unsupportedFormat(t *testing.T) {
+	_, err := RenderTranscript(GroupScenes(testQuotes), TranscriptOptions{Format: "pdf"})
+	assert.ErrorContains(t, err, `unsupported transcript format "pdf"`)
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"Bears. Beets. Battlestar Galactica."}, wrap("Bears. Beets. Battlestar Galactica.", 0))
+	assert.Equal(t, []string{"Bears.", "Beets.", "Battlestar", "Galactica."}, wrap("Bears. Beets. Battlestar Galactica.", 6))
+	assert.Equal(t, []string{"Bears. Beets.", "Battlestar", "Galactica."}, wrap("Bears.  Beets.\nBattlestar Galactica.", 15))
+	assert.Equal(t, []string{""}, wrap("", 10))
+}
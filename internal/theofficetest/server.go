@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package theofficetest provides a stand-in for theOffice API, serving a
+// fixture dataset with optional fault injection, for use in tests and demos.
+package theofficetest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+)
+
+var (
+	//go:embed fixtures/quotes.json
+	quotesFixture []byte
+
+	//go:embed fixtures/connections.json
+	connectionsFixture []byte
+)
+
+// Dataset is the data served by a Server.
+type Dataset struct {
+	Quotes      []theoffice.Quote
+	Connections map[int][]theoffice.Connection
+}
+
+// DefaultDataset returns the bundled fixture dataset.
+func DefaultDataset() Dataset {
+	d := Dataset{
+		Connections: map[int][]theoffice.Connection{},
+	}
+
+	if err := json.Unmarshal(quotesFixture, &d.Quotes); err != nil {
+		panic(fmt.Sprintf("decoding quotes fixture: %s", err))
+	}
+
+	var seasons []struct {
+		Season      int                    `json:"season"`
+		Connections []theoffice.Connection `json:"connections"`
+	}
+	if err := json.Unmarshal(connectionsFixture, &seasons); err != nil {
+		panic(fmt.Sprintf("decoding connections fixture: %s", err))
+	}
+	for _, s := range seasons {
+		d.Connections[s.Season] = s.Connections
+	}
+
+	return d
+}
+
+// Faults describes failures injected into responses.
+type Faults struct {
+	// Latency delays every response.
+	Latency time.Duration
+
+	// StatusCode, when non-zero, is returned instead of the dataset.
+	StatusCode int
+
+	// RetryAfter is sent as the Retry-After header, in whole seconds, with
+	// StatusCode. The header is always sent with http.StatusTooManyRequests.
+	RetryAfter time.Duration
+
+	// MalformedJSON truncates successful response bodies.
+	MalformedJSON bool
+
+	// Count limits the faults to the first Count requests. Zero applies them
+	// to every request.
+	Count int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithDataset serves d instead of the bundled fixtures.
+func WithDataset(d Dataset) Option {
+	return func(s *Server) {
+		s.dataset = d
+	}
+}
+
+// WithFaults injects f into responses.
+func WithFaults(f Faults) Option {
+	return func(s *Server) {
+		s.faults = f
+	}
+}
+
+// Server implements the REST endpoints of theOffice API.
+type Server struct {
+	*httptest.Server
+
+	dataset Dataset
+
+	mu       sync.Mutex
+	faults   Faults
+	requests int
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := NewUnstartedServer(opts...)
+	s.Start()
+
+	return s
+}
+
+// NewUnstartedServer returns a new Server but doesn't start it.
+func NewUnstartedServer(opts ...Option) *Server {
+	s := &Server{
+		dataset: DefaultDataset(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewUnstartedServer(s.Handler())
+
+	return s
+}
+
+// Handler returns the http.Handler serving the API routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /season/{season}/format/quotes", s.handleQuotes)
+	mux.HandleFunc("GET /season/{season}/episode/{episode}", s.handleQuotes)
+	mux.HandleFunc("GET /season/{season}/format/connections", s.handleConnections)
+
+	return s.withFaults(mux)
+}
+
+// SetFaults replaces the faults injected into subsequent responses and
+// resets the request count they are measured against.
+func (s *Server) SetFaults(f Faults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = f
+	s.requests = 0
+}
+
+// Requests returns the number of requests served since the Server started or
+// its faults were last set.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) withFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		faults := s.faults
+		active := faults.Count == 0 || s.requests <= faults.Count
+		s.mu.Unlock()
+
+		if !active {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if faults.Latency > 0 {
+			select {
+			case <-time.After(faults.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if faults.StatusCode != 0 {
+			if faults.RetryAfter > 0 || faults.StatusCode == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", strconv.Itoa(int(faults.RetryAfter.Seconds())))
+			}
+			http.Error(w, http.StatusText(faults.StatusCode), faults.StatusCode)
+			return
+		}
+
+		if faults.MalformedJSON {
+			next.ServeHTTP(&truncatingWriter{ResponseWriter: w}, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleQuotes(w http.ResponseWriter, r *http.Request) {
+	season, ok := pathInt(w, r, "season")
+	if !ok {
+		return
+	}
+
+	episode := 0
+	if r.PathValue("episode") != "" {
+		if episode, ok = pathInt(w, r, "episode"); !ok {
+			return
+		}
+	}
+
+	quotes := []theoffice.Quote{}
+	for _, q := range s.dataset.Quotes {
+		if q.Season == season && (episode == 0 || q.Episode == episode) {
+			quotes = append(quotes, q)
+		}
+	}
+
+	if len(quotes) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, quotes)
+}
+
+func (s *Server) handleConnections(w http.ResponseWriter, r *http.Request) {
+	season, ok := pathInt(w, r, "season")
+	if !ok {
+		return
+	}
+
+	connections, ok := s.dataset.Connections[season]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, connections)
+}
+
+func pathInt(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	v, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s %q", name, r.PathValue(name)), http.StatusBadRequest)
+		return 0, false
+	}
+
+	return v, true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// truncatingWriter drops the second half of everything written to it, so
+// that valid JSON arrives malformed.
+type truncatingWriter struct {
+	http.ResponseWriter
+}
+
+func (w *truncatingWriter) Write(b []byte) (int, error) {
+	if _, err := w.ResponseWriter.Write(b[:len(b)/2]); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package theofficetest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anGie44/terraform-provider-theoffice/internal/theoffice"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(t *testing.T, srv *Server) *theoffice.Client {
+	t.Helper()
+
+	c, err := theoffice.NewClient(&theoffice.Config{
+		Address: srv.URL,
+	})
+	assert.NoError(t, err)
+
+	return c
+}
+
+func TestServerQuotes_season(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := newClient(t, srv).GetQuotes(context.Background(), 1, 0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 26, len(resp.Quotes))
+	assert.Equal(t, "Pilot", resp.Quotes[0].EpisodeName)
+	assert.Equal(t, "Michael", resp.Quotes[0].Character)
+	assert.Equal(t, "Diversity Day", resp.Quotes[25].EpisodeName)
+}
+
+func TestServerQuotes_episode(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := newClient(t, srv).GetQuotes(context.Background(), 2, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 9, len(resp.Quotes))
+	for _, q := range resp.Quotes {
+		assert.Equal(t, 2, q.Season)
+		assert.Equal(t, 1, q.Episode)
+		assert.Equal(t, "The Dundies", q.EpisodeName)
+	}
+}
+
+func TestServerQuotes_notFound(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, err := newClient(t, srv).GetQuotes(context.Background(), 1, 9)
+	assert.ErrorContains(t, err, "bad status (404)")
+}
+
+func TestServerConnections(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := newClient(t, srv).GetConnections(context.Background(), 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(resp.Connections))
+	assert.Equal(t, 1, resp.Connections[0].Episode)
+	assert.Equal(t, "Pilot", resp.Connections[0].EpisodeName)
+	assert.Equal(t, theoffice.Link{Source: "Jim", Target: "Michael", Value: 4}, resp.Connections[0].Links[0])
+	assert.Equal(t, 4, len(resp.Connections[0].Nodes))
+}
+
+func TestServerDataset(t *testing.T) {
+	srv := NewServer(WithDataset(Dataset{
+		Quotes: []theoffice.Quote{
+			{Season: 5, Episode: 14, Scene: 1, EpisodeName: "Stress Relief", Character: "Dwight", Quote: "Today, smoking is going to save lives."},
+		},
+	}))
+	defer srv.Close()
+
+	resp, err := newClient(t, srv).GetQuotes(context.Background(), 5, 14)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(resp.Quotes))
+	assert.Equal(t, "Stress Relief", resp.Quotes[0].EpisodeName)
+}
+
+func TestServerFaults_statusCode(t *testing.T) {
+	srv := NewServer(WithFaults(Faults{StatusCode: http.StatusServiceUnavailable}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/season/1/format/quotes")
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(t, "", res.Header.Get("Retry-After"))
+}
+
+func TestServerFaults_retryAfter(t *testing.T) {
+	srv := NewServer(WithFaults(Faults{StatusCode: http.StatusTooManyRequests, Count: 1}))
+	defer srv.Close()
+
+	resp, err := newClient(t, srv).GetQuotes(context.Background(), 1, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 16, len(resp.Quotes))
+	assert.Equal(t, 2, srv.Requests())
+}
+
+func TestServerFaults_malformedJSON(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetFaults(Faults{MalformedJSON: true})
+
+	_, err := newClient(t, srv).GetConnections(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Equal(t, 1, srv.Requests())
+}
+
+func TestServerFaults_latency(t *testing.T) {
+	srv := NewServer(WithFaults(Faults{Latency: time.Second}))
+	defer srv.Close()
+
+	client := &http.Client{Timeout: 10 * time.Millisecond}
+	_, err := client.Get(srv.URL + "/season/1/format/quotes")
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestServerDataset_matchesSchema(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	c, err := theoffice.NewClient(&theoffice.Config{
+		Address:        srv.URL,
+		StrictDecoding: true,
+	})
+	assert.NoError(t, err)
+
+	for season := 1; season <= 2; season++ {
+		_, err := c.GetQuotes(context.Background(), season, 0)
+		assert.NoError(t, err)
+
+		_, err = c.GetConnections(context.Background(), season)
+		assert.NoError(t, err)
+	}
+}